@@ -0,0 +1,138 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe/series"
+	"runtime"
+	"sync"
+)
+
+// ApplySettings defines a struct that contains settings for applying functions over a DataFrame, allows for optional
+// settings
+type ApplySettings struct {
+	// Parallel runs the function on a pool of Workers goroutines, so it must be safe to call concurrently
+	Parallel bool
+	// Workers defaults to the number of CPUs
+	Workers int
+}
+
+var defaultApplySettings = ApplySettings{
+	Parallel: false,
+}
+
+// Row gives access to the values of a single row of a DataFrame by column name.
+type Row struct {
+	df       DataFrame
+	position int
+}
+
+// Position returns the position of the row in the DataFrame.
+func (r Row) Position() int {
+	return r.position
+}
+
+// Label returns the index label of the row.
+func (r Row) Label() any {
+	return r.df.index.Val(r.position)
+}
+
+// Get returns the value of the row in the specified column, or nil if it is NA.
+func (r Row) Get(name string) any {
+	s := r.df.columns[r.df.columnPosition(name)]
+	if s.Elem(r.position).IsNA() {
+		return nil
+	}
+	return s.Val(r.position)
+}
+
+// IsNA returns true if the value of the row in the specified column is NA.
+func (r Row) IsNA(name string) bool {
+	return r.df.columns[r.df.columnPosition(name)].Elem(r.position).IsNA()
+}
+
+// parallelFor calls f for every position from 0 to n-1, on a pool of worker goroutines in parallel mode. A panic in
+// any call is raised again once every worker has stopped.
+func parallelFor(n int, settings []ApplySettings, f func(i int)) {
+	if len(settings) > 1 {
+		panic(fmt.Errorf("only one settings struct allowed"))
+	}
+	if len(settings) == 0 {
+		settings = append(settings, defaultApplySettings)
+	}
+
+	if !settings[0].Parallel {
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+		return
+	}
+
+	workers := settings[0].Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	positions := make(chan int)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var failure any
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { failure = r })
+					// Drain the remaining positions so the producer is not blocked
+					for range positions {
+					}
+				}
+			}()
+
+			for i := range positions {
+				f(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		positions <- i
+	}
+	close(positions)
+	wg.Wait()
+
+	if failure != nil {
+		panic(failure)
+	}
+}
+
+// ApplyRows returns a series.Series of type t with the specified name, holding the result of f for each row of the
+// DataFrame. Results are converted to type t as by series.Element.Set, and nil results are NA.
+func (df DataFrame) ApplyRows(name string, t series.Type, f func(row Row) any, settings ...ApplySettings) series.Series {
+	results := make([]any, df.nrows)
+	parallelFor(df.nrows, settings, func(i int) {
+		results[i] = f(Row{df: df, position: i})
+	})
+
+	result := series.NewEmptySeries(t, df.nrows, name)
+	for i, v := range results {
+		result.Elem(i).Set(v)
+	}
+	return result
+}
+
+// ApplyColumns returns a new DataFrame with f applied to each column, keeping the index of the DataFrame. Each result
+// must have the same length as the DataFrame.
+func (df DataFrame) ApplyColumns(f func(s series.Series) series.Series, settings ...ApplySettings) DataFrame {
+	columns := make([]series.Series, df.ncols)
+	parallelFor(df.ncols, settings, func(j int) {
+		columns[j] = f(df.columns[j].Copy())
+	})
+
+	for _, s := range columns {
+		if s.Len() != df.nrows {
+			panic(fmt.Errorf("series length %v does not match DataFrame length %v", s.Len(), df.nrows))
+		}
+	}
+	return New(columns...).SetIndex(df.index)
+}
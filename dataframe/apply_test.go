@@ -0,0 +1,67 @@
+package dataframe
+
+import (
+	"github.com/chriso345/golab/dataframe/series"
+	"testing"
+)
+
+func TestDataFrame_ApplyRows(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2, 3, 4}, series.Int, "A"),
+		series.New([]float64{0.5, 1.5, 2.5, 3.5}, series.Float, "B"),
+	)
+	df.Columns()[1].Elem(2).Set(nil)
+	expected := "{Total [1.5 3.5 NaN 7.5] float}"
+
+	total := func(row Row) any {
+		if row.IsNA("B") {
+			return nil
+		}
+		return float64(row.Get("A").(int)) + row.Get("B").(float64)
+	}
+
+	for _, settings := range []ApplySettings{{}, {Parallel: true, Workers: 3}} {
+		result := df.ApplyRows("Total", series.Float, total, settings)
+		if result.String() != expected {
+			t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+		}
+	}
+
+	positions := df.ApplyRows("Position", series.Int, func(row Row) any { return row.Position() })
+	expected = "{Position [0 1 2 3] int}"
+	if positions.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, positions.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected ApplyRows to panic, but it did not")
+		}
+	}()
+
+	df.ApplyRows("Missing", series.Int, func(row Row) any { return row.Get("C") }, ApplySettings{Parallel: true})
+}
+
+func TestDataFrame_ApplyColumns(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2, 3, 4}, series.Int, "A"),
+		series.New([]float64{0.5, 1.5, 2.5, 3.5}, series.Float, "B"),
+	)
+	df.Columns()[1].Elem(2).Set(nil)
+	expected := "    A    B\n0   1  0.5\n1   3    2\n2   6  NaN\n3  10  5.5"
+
+	for _, settings := range []ApplySettings{{}, {Parallel: true}} {
+		result := df.ApplyColumns(func(s series.Series) series.Series { return s.CumSum() }, settings)
+		if result.String() != expected {
+			t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected ApplyColumns to panic, but it did not")
+		}
+	}()
+
+	df.ApplyColumns(func(s series.Series) series.Series { return s.Slice(0, 1) })
+}
@@ -0,0 +1,117 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe/series"
+)
+
+// Rename returns a copy of the DataFrame with columns renamed according to a map of old names to new names.
+func (df DataFrame) Rename(names map[string]string) DataFrame {
+	for old := range names {
+		df.columnPosition(old)
+	}
+
+	result := df.Copy()
+	for j, s := range result.columns {
+		if name, ok := names[s.Name]; ok {
+			result.columns[j].Name = name
+		}
+	}
+
+	if err := checkColumnNames(result.columns...); err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// Insert inserts a copy of a series.Series into the DataFrame inplace, at column position pos.
+func (df *DataFrame) Insert(pos int, s series.Series) {
+	if pos < 0 || pos > df.ncols {
+		panic(fmt.Errorf("column position %v out of range", pos))
+	}
+	if s.Len() != df.nrows {
+		panic(fmt.Errorf("series length %v does not match DataFrame length %v", s.Len(), df.nrows))
+	}
+
+	columns := make([]series.Series, 0, df.ncols+1)
+	columns = append(columns, df.columns[:pos]...)
+	columns = append(columns, s.Copy())
+	columns = append(columns, df.columns[pos:]...)
+	if err := checkColumnNames(columns...); err != nil {
+		panic(err)
+	}
+
+	df.columns = columns
+	df.ncols++
+}
+
+// SetColumn sets the column with the specified name to a copy of a series.Series inplace, replacing the existing
+// column in its position or adding a new column to the right of the DataFrame.
+func (df *DataFrame) SetColumn(name string, s series.Series) {
+	if s.Len() != df.nrows {
+		panic(fmt.Errorf("series length %v does not match DataFrame length %v", s.Len(), df.nrows))
+	}
+
+	column := s.Copy()
+	column.Name = name
+	for j := range df.columns {
+		if df.columns[j].Name == name {
+			df.columns[j] = column
+			return
+		}
+	}
+
+	df.columns = append(df.columns, column)
+	df.ncols++
+}
+
+// Assign returns a copy of the DataFrame with the column name set to the series.Series computed by f from the
+// DataFrame, replacing an existing column or adding a new column to the right.
+func (df DataFrame) Assign(name string, f func(df DataFrame) series.Series) DataFrame {
+	result := df.Copy()
+	result.SetColumn(name, f(df))
+	return result
+}
+
+// ReorderColumns returns a copy of the DataFrame with its columns in the specified order, which must name every column
+// once.
+func (df DataFrame) ReorderColumns(names ...string) DataFrame {
+	if len(names) != df.ncols {
+		panic(fmt.Errorf("expected %v columns, but got %v", df.ncols, len(names)))
+	}
+
+	columns := make([]int, len(names))
+	for i, name := range names {
+		columns[i] = df.columnPosition(name)
+	}
+
+	seen := make(map[int]struct{}, len(columns))
+	for i, j := range columns {
+		if _, ok := seen[j]; ok {
+			panic(fmt.Errorf("column %v specified more than once", names[i]))
+		}
+		seen[j] = struct{}{}
+	}
+
+	return df.take(df.allRows(), columns)
+}
+
+// DropColumns returns a copy of the DataFrame without the specified columns, leaving the original DataFrame unchanged.
+func (df DataFrame) DropColumns(names ...string) DataFrame {
+	dropped := make(map[int]struct{}, len(names))
+	for _, name := range names {
+		dropped[df.columnPosition(name)] = struct{}{}
+	}
+
+	var columns []int
+	for j := range df.columns {
+		if _, ok := dropped[j]; !ok {
+			columns = append(columns, j)
+		}
+	}
+	if len(columns) == 0 {
+		panic("cannot drop every column")
+	}
+
+	return df.take(df.allRows(), columns)
+}
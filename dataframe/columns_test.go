@@ -0,0 +1,159 @@
+package dataframe
+
+import (
+	"github.com/chriso345/golab/dataframe/series"
+	"testing"
+)
+
+func TestDataFrame_Rename(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2}, series.Int, "A"),
+		series.New([]string{"x", "y"}, series.String, "B"),
+		series.New([]float64{1.5, 2.5}, series.Float, "C"),
+	)
+	expected := "   One  B  Three\n0    1  x    1.5\n1    2  y    2.5"
+
+	result := df.Rename(map[string]string{"A": "One", "C": "Three"})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	// Rename returns a copy
+	result.Columns()[0].Elem(0).Set(10)
+	if df.Names()[0] != "A" || df.At(0, 0) != 1 {
+		t.Errorf("Expected Rename to leave the original unchanged")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Rename to panic, but it did not")
+		}
+	}()
+
+	df.Rename(map[string]string{"A": "B"})
+}
+
+func TestDataFrame_Insert(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2}, series.Int, "A"),
+		series.New([]string{"x", "y"}, series.String, "B"),
+		series.New([]float64{1.5, 2.5}, series.Float, "C"),
+	)
+	s := series.New([]bool{true, false}, series.Boolean, "D")
+	expected := "   A      D  B    C\n0  1   true  x  1.5\n1  2  false  y  2.5"
+
+	// Insert modifies the DataFrame inplace, and holds a copy of the series
+	df.Insert(1, s)
+	s.Elem(0).Set(false)
+
+	if df.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, df.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Insert to panic, but it did not")
+		}
+	}()
+
+	df.Insert(5, s)
+}
+
+func TestDataFrame_SetColumn(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2}, series.Int, "A"),
+		series.New([]string{"x", "y"}, series.String, "B"),
+		series.New([]float64{1.5, 2.5}, series.Float, "C"),
+	)
+	expected := "   A  B    C    D\n0  1  p  1.5  NaN\n1  2  q  2.5    1"
+
+	// SetColumn modifies the DataFrame inplace, replacing columns in their position or adding them to the right
+	df.SetColumn("B", series.New([]string{"p", "q"}, series.String, "Other"))
+	d := series.New([]int{0, 1}, series.Int, "D")
+	d.Elem(0).Set(nil)
+	df.SetColumn("D", d)
+
+	if df.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, df.String())
+	}
+}
+
+func TestDataFrame_Assign(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2}, series.Int, "A"),
+		series.New([]string{"x", "y"}, series.String, "B"),
+		series.New([]float64{1.5, 2.5}, series.Float, "C"),
+	)
+	expected := "   A  B    C    D\n0  1  x  1.5  2.5\n1  2  y  2.5  4.5"
+
+	result := df.Assign("D", func(df DataFrame) series.Series {
+		d := series.NewEmptySeries(series.Float, 2, "D")
+		for i := 0; i < 2; i++ {
+			d.Elem(i).Set(float64(df.At(i, 0).(int)) + df.At(i, 2).(float64))
+		}
+		return d
+	})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	// Assign returns a copy
+	original := "   A  B    C\n0  1  x  1.5\n1  2  y  2.5"
+	if df.String() != original {
+		t.Errorf("Expected Assign to leave the original unchanged:\n%v\nGot:\n%v", original, df.String())
+	}
+}
+
+func TestDataFrame_ReorderColumns(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2}, series.Int, "A"),
+		series.New([]string{"x", "y"}, series.String, "B"),
+		series.New([]float64{1.5, 2.5}, series.Float, "C"),
+	)
+	expected := "     C  A  B\n0  1.5  1  x\n1  2.5  2  y"
+
+	result := df.ReorderColumns("C", "A", "B")
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected ReorderColumns to panic, but it did not")
+		}
+	}()
+
+	df.ReorderColumns("A", "A", "B")
+}
+
+func TestDataFrame_DropColumns(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2}, series.Int, "A"),
+		series.New([]string{"x", "y"}, series.String, "B"),
+		series.New([]float64{1.5, 2.5}, series.Float, "C"),
+	)
+	expected := "   B\n0  x\n1  y"
+
+	result := df.DropColumns("A", "C")
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	// DropColumns returns a copy, unlike Drop
+	original := "   A  B    C\n0  1  x  1.5\n1  2  y  2.5"
+	if df.String() != original {
+		t.Errorf("Expected DropColumns to leave the original unchanged:\n%v\nGot:\n%v", original, df.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected DropColumns to panic, but it did not")
+		}
+	}()
+
+	df.DropColumns("Missing")
+}
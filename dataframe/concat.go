@@ -0,0 +1,195 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe/series"
+)
+
+// commonType returns the type that values of each of the specified types can be stored in without loss. Numeric
+// types are widened to float, and any other mix of types falls back to string.
+func commonType(types ...series.Type) series.Type {
+	common := types[0]
+	for _, t := range types[1:] {
+		if t == common {
+			continue
+		}
+
+		if isNumericType(t) && isNumericType(common) {
+			if t == series.Float || common == series.Float {
+				common = series.Float
+			} else {
+				common = series.Int
+			}
+			continue
+		}
+		return series.String
+	}
+	return common
+}
+
+// isNumericType returns true if t is one of the numeric series types.
+func isNumericType(t series.Type) bool {
+	return t == series.Int || t == series.Float || t == series.Boolean
+}
+
+// stack places the series on top of one another in a new series of type t. A missing series, marked by a false entry
+// in present, is filled with length NA values.
+func stack(name string, t series.Type, se []series.Series, present []bool, lengths []int) series.Series {
+	total := 0
+	for _, l := range lengths {
+		total += l
+	}
+
+	result := newEmptyCommon(t, se, present, total, name)
+	offset := 0
+	for k, s := range se {
+		for i := 0; i < lengths[k]; i++ {
+			if present[k] {
+				copyElement(result.Elem(offset+i), s, i)
+			} else {
+				result.Elem(offset + i).Set(nil)
+			}
+		}
+		offset += lengths[k]
+	}
+	return result
+}
+
+// newEmptyCommon creates a series of type t to hold the values of the present series. A categorical series takes the
+// categories of every present series in order of first appearance.
+func newEmptyCommon(t series.Type, se []series.Series, present []bool, size int, name string) series.Series {
+	if t != series.Categorical {
+		return series.NewEmptySeries(t, size, name)
+	}
+
+	seen := make(map[string]struct{})
+	categories := []string{}
+	for k, s := range se {
+		if !present[k] {
+			continue
+		}
+		for _, c := range s.Categories() {
+			if _, ok := seen[c]; !ok {
+				seen[c] = struct{}{}
+				categories = append(categories, c)
+			}
+		}
+	}
+
+	result := series.NewCategorical(make([]string, size), categories, name)
+	for i := 0; i < size; i++ {
+		result.Elem(i).Set(nil)
+	}
+	return result
+}
+
+// ConcatRows stacks DataFrames on top of one another. Columns are aligned by name in order of first appearance, and
+// a column missing from one of the DataFrames is filled with NA values. Columns with differing types are widened to a
+// common type. The indices are concatenated if they share a type, otherwise the index is reset.
+func ConcatRows(dfs ...DataFrame) DataFrame {
+	if len(dfs) == 0 {
+		panic("no DataFrames to concatenate")
+	}
+
+	var names []string
+	types := make(map[string][]series.Type)
+	for _, df := range dfs {
+		for _, s := range df.columns {
+			if _, ok := types[s.Name]; !ok {
+				names = append(names, s.Name)
+			}
+			types[s.Name] = append(types[s.Name], s.Type())
+		}
+	}
+
+	lengths := make([]int, len(dfs))
+	for k, df := range dfs {
+		lengths[k] = df.nrows
+	}
+
+	columns := make([]series.Series, len(names))
+	for j, name := range names {
+		se := make([]series.Series, len(dfs))
+		present := make([]bool, len(dfs))
+		for k, df := range dfs {
+			for _, s := range df.columns {
+				if s.Name == name {
+					se[k] = s
+					present[k] = true
+					break
+				}
+			}
+		}
+		columns[j] = stack(name, commonType(types[name]...), se, present, lengths)
+	}
+
+	df := New(columns...)
+
+	indices := make([]series.Series, len(dfs))
+	present := make([]bool, len(dfs))
+	for k, d := range dfs {
+		if d.index.Type() != dfs[0].index.Type() {
+			return df
+		}
+		indices[k] = d.index
+		present[k] = true
+	}
+	df.index = stack(dfs[0].index.Name, dfs[0].index.Type(), indices, present, lengths)
+	return df
+}
+
+// AppendRows returns a new DataFrame with the rows of other placed below the rows of the DataFrame, following the
+// alignment rules of ConcatRows.
+func (df DataFrame) AppendRows(other DataFrame) DataFrame {
+	return ConcatRows(df, other)
+}
+
+// ConcatColumns places DataFrames side by side, aligning their rows on the index. The resulting index holds every
+// label in order of first appearance, and labels missing from one of the DataFrames are filled with NA values.
+func ConcatColumns(dfs ...DataFrame) DataFrame {
+	if len(dfs) == 0 {
+		panic("no DataFrames to concatenate")
+	}
+
+	var labels []any
+	seen := make(map[any]struct{})
+	lookups := make([]map[any]int, len(dfs))
+	for k, df := range dfs {
+		lookups[k] = make(map[any]int, df.nrows)
+		for i := 0; i < df.nrows; i++ {
+			label := df.index.Val(i)
+			if _, ok := lookups[k][label]; ok {
+				panic(fmt.Errorf("duplicate index label %v", label))
+			}
+			lookups[k][label] = i
+
+			if _, ok := seen[label]; !ok {
+				seen[label] = struct{}{}
+				labels = append(labels, label)
+			}
+		}
+	}
+
+	var columns []series.Series
+	for k, df := range dfs {
+		rows := make([]int, len(labels))
+		for i, label := range labels {
+			row, ok := lookups[k][label]
+			if !ok {
+				row = -1
+			}
+			rows[i] = row
+		}
+
+		for _, s := range df.columns {
+			columns = append(columns, s.Take(rows...))
+		}
+	}
+
+	index := series.NewEmptySeries(dfs[0].index.Type(), len(labels), dfs[0].index.Name)
+	for i, label := range labels {
+		index.Elem(i).Set(label)
+	}
+
+	return New(columns...).SetIndex(index)
+}
@@ -0,0 +1,100 @@
+package dataframe
+
+import (
+	"github.com/chriso345/golab/dataframe/series"
+	"testing"
+	"time"
+)
+
+func TestConcatRows(t *testing.T) {
+	expected := "   Integers  Floats  Strings\n0         1     4.4      NaN\n1         2     5.5      NaN\n0         3     NaN        a"
+
+	df1 := New(
+		series.New([]int{1, 2}, series.Int, "Integers"),
+		series.New([]float64{4.4, 5.5}, series.Float, "Floats"),
+	)
+	df2 := New(
+		series.New([]string{"a"}, series.String, "Strings"),
+		series.New([]int{3}, series.Int, "Integers"),
+	)
+	result := ConcatRows(df1, df2)
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestConcatRows_CommonType(t *testing.T) {
+	df1 := New(series.New([]int{1, 2}, series.Int, "Values"))
+	df2 := New(series.New([]float64{3.5}, series.Float, "Values"))
+	df3 := New(series.New([]string{"a"}, series.String, "Values"))
+
+	result := ConcatRows(df1, df2)
+	if result.Column("Values").Type() != series.Float {
+		t.Errorf("Expected type %v, got %v", series.Float, result.Column("Values").Type())
+	}
+
+	result = ConcatRows(df1, df3)
+	if result.Column("Values").Type() != series.String {
+		t.Errorf("Expected type %v, got %v", series.String, result.Column("Values").Type())
+	}
+}
+
+func TestConcatRows_Datetime(t *testing.T) {
+	expected := "{Values [1 2024-03-01T12:30:00Z] string}"
+
+	df1 := New(series.New([]int{1}, series.Int, "Values"))
+	df2 := New(series.New([]time.Time{time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)}, series.Datetime, "Values"))
+	result := ConcatRows(df1, df2)
+
+	if result.Column("Values").String() != expected {
+		t.Errorf("Expected %v, got %v", expected, result.Column("Values").String())
+	}
+}
+
+func TestDataFrame_AppendRows(t *testing.T) {
+	expected := "   Integers\n0         1\n1         2\n0         3"
+
+	df1 := New(series.New([]int{1, 2}, series.Int, "Integers"))
+	df2 := New(series.New([]int{3}, series.Int, "Integers"))
+	result := df1.AppendRows(df2)
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	original := "   Integers\n0         1\n1         2"
+	if df1.String() != original {
+		t.Errorf("Expected original DataFrame to be unchanged:\n%v\nGot:\n%v", original, df1.String())
+	}
+}
+
+func TestConcatColumns(t *testing.T) {
+	expected := "   Integers  Floats\n0         1     NaN\n1         2     5.5\n2         3     4.4\n3       NaN     6.6"
+
+	df1 := New(series.New([]int{1, 2, 3}, series.Int, "Integers"))
+	df2 := New(series.New([]float64{4.4, 5.5, 6.6}, series.Float, "Floats"))
+	df2 = df2.SetIndex(series.New([]int{2, 1, 3}, series.Int, "Index"))
+	result := ConcatColumns(df1, df2)
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestConcatRows_Categorical(t *testing.T) {
+	a := New(series.NewCategorical([]string{"x", "y"}, []string{"x", "y"}, "Category"))
+	b := New(series.NewCategorical([]string{"z", "x"}, []string{"z", "x"}, "Category"))
+	expected := "{Category [x y z x] category}"
+
+	result := ConcatRows(a, b).Column("Category")
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	categories := result.Categories()
+	if len(categories) != 3 || categories[2] != "z" {
+		t.Errorf("Expected:\n%v\nGot:\n%v", []string{"x", "y", "z"}, categories)
+	}
+}
@@ -1,6 +1,7 @@
 package dataframe
 
 import (
+	"errors"
 	"fmt"
 	"github.com/chriso345/golab/dataframe/series"
 	"strings"
@@ -39,6 +40,9 @@ func New(se ...series.Series) DataFrame {
 	if err != nil {
 		panic(err)
 	}
+	if err = checkColumnNames(columns...); err != nil {
+		panic(err)
+	}
 
 	df := DataFrame{
 		index:   index,
@@ -47,8 +51,6 @@ func New(se ...series.Series) DataFrame {
 		nrows:   nrows,
 	}
 
-	// TODO: Currently assuming that column names are unique
-
 	return df
 }
 
@@ -72,11 +74,23 @@ func checkColumnDimensions(se ...series.Series) (ncols int, nrows int, err error
 	return
 }
 
+// checkColumnNames checks that all series.Series have unique names.
+func checkColumnNames(se ...series.Series) error {
+	seen := make(map[string]struct{}, len(se))
+	for _, s := range se {
+		if _, ok := seen[s.Name]; ok {
+			return fmt.Errorf("duplicate column name %v", s.Name)
+		}
+		seen[s.Name] = struct{}{}
+	}
+	return nil
+}
+
 // String is the Stringer implementation for DataFrame.
 func (df DataFrame) String() string {
 	var sb strings.Builder
 
-	maxIndexOffset := len(fmt.Sprint(df.index.Val(df.nrows - 1)))
+	maxIndexOffset := 0
 	for i := 0; i < df.index.Len(); i++ {
 		temp := len(fmt.Sprint(df.index.Val(i)))
 		if temp > maxIndexOffset {
@@ -84,6 +98,16 @@ func (df DataFrame) String() string {
 		}
 	}
 
+	widths := make([]int, df.ncols)
+	for k, s := range df.columns {
+		widths[k] = len(s.Name)
+		for i := 0; i < df.nrows; i++ {
+			if l := len(cell(s, i)); l > widths[k] {
+				widths[k] = l
+			}
+		}
+	}
+
 	for i, s := range df.columns {
 		if i == 0 {
 			for j := 0; j < maxIndexOffset; j++ {
@@ -91,11 +115,13 @@ func (df DataFrame) String() string {
 			}
 		}
 		sb.WriteString("  ")
+		for j := 0; j < widths[i]-len(s.Name); j++ {
+			sb.WriteString(" ")
+		}
 		sb.WriteString(s.Name)
 	}
 
 	sb.WriteString("\n")
-	// TODO: fix formatting implementation to be tidier
 	for i := 0; i < df.nrows; i++ {
 		indexOffset := maxIndexOffset - len(fmt.Sprint(df.index.Val(i)))
 		for j := 0; j < indexOffset; j++ {
@@ -105,10 +131,9 @@ func (df DataFrame) String() string {
 		sb.WriteString("  ")
 
 		for k, s := range df.columns {
-			// Get length of column
-			target := fmt.Sprint(s.Val(i))
+			target := cell(s, i)
 
-			for j := 0; j < len(s.Name)-len(target); j++ {
+			for j := 0; j < widths[k]-len(target); j++ {
 				sb.WriteString(" ")
 			}
 			sb.WriteString(target)
@@ -124,6 +149,17 @@ func (df DataFrame) String() string {
 	return sb.String()
 }
 
+// cell returns the formatted value of element i of s, with NA values shown as NaN and runes shown as characters.
+func cell(s series.Series, i int) string {
+	if s.Elem(i).IsNA() {
+		return "NaN"
+	}
+	if r, ok := s.Val(i).(rune); ok {
+		return string(r)
+	}
+	return fmt.Sprint(s.Val(i))
+}
+
 // Shape returns the dimensions of the DataFrame in the form (nrows, ncols).
 func (df DataFrame) Shape() (int, int) {
 	return df.nrows, df.ncols
@@ -250,61 +286,21 @@ func (df DataFrame) Swap(row1, row2 int) {
 	}
 }
 
-// Sort sorts the DataFrame inplace according to the specified columns.
+// Sort sorts the DataFrame inplace in ascending order according to the specified columns, with NA values placed last.
 func (df DataFrame) Sort(columns ...string) {
-	if len(columns) == 0 {
-		panic("no columns specified")
-	}
-
-	if len(columns) > 1 {
-		panic("> 1 column not yet implemented")
-	}
-
-	column := df.Column(columns[0])
-
-	// Sort via bubble sort according to specified column
-	for i := 0; i < df.nrows; i++ {
-		for j := 0; j < df.nrows-i-1; j++ {
-			switch column.Type() {
-			case series.Int:
-				if column.Val(j).(int) > column.Val(j+1).(int) {
-					df.Swap(j, j+1)
-				}
-			case series.Float:
-				if column.Val(j).(float64) > column.Val(j+1).(float64) {
-					df.Swap(j, j+1)
-				}
-			}
-		}
-	}
+	df.Order(df.sortedIndex(columns, SortSettings{})...)
 }
 
-// Order orders the DataFrame inplace according to the specified positions.
+// Order orders the DataFrame inplace according to the specified positions, such that row i of the result is row
+// positions[i] of the original DataFrame.
 func (df DataFrame) Order(positions ...int) DataFrame {
 	if len(positions) != df.nrows {
 		panic("positions must be the same length as the DataFrame")
 	}
 
-	// Need to copy otherwise positions collection will mutate
-	newPositions := make([]int, df.nrows)
-	for i, pos := range positions {
-		newPositions[i] = pos
-	}
-
-	for newPos, oldPos := range newPositions {
-		if oldPos == newPos {
-			continue
-		}
-
-		df.Swap(oldPos, newPos)
-
-		for i, pos := range newPositions {
-			if pos == newPos {
-				newPositions[i] = oldPos
-				newPositions[newPos] = newPos
-				break
-			}
-		}
+	df.index.Order(positions...)
+	for _, s := range df.columns {
+		s.Order(positions...)
 	}
 
 	return df
@@ -315,6 +311,9 @@ func (df *DataFrame) Append(s series.Series) {
 	if s.Len() != df.nrows {
 		panic(fmt.Errorf("series length %v does not match DataFrame length %v", s.Len(), df.nrows))
 	}
+	if err := checkColumnNames(append(df.columns, s)...); err != nil {
+		panic(err)
+	}
 
 	df.columns = append(df.columns, s)
 	df.ncols++
@@ -366,3 +365,26 @@ func (df *DataFrame) Drop(name string) series.Series {
 	panic(fmt.Errorf("column %v not found", name))
 }
 
+// AsType returns a copy of the DataFrame with the specified columns cast to new types. The errors of every column
+// which could not be cast exactly are joined together, see series.Series.AsType.
+func (df DataFrame) AsType(types map[string]series.Type, options ...series.CastOptions) (DataFrame, error) {
+	for name := range types {
+		df.columnPosition(name)
+	}
+
+	result := df.Copy()
+	var errs []error
+	for j, s := range result.columns {
+		t, ok := types[s.Name]
+		if !ok {
+			continue
+		}
+
+		cast, err := s.AsType(t, options...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		result.columns[j] = cast
+	}
+	return result, errors.Join(errs...)
+}
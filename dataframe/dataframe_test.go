@@ -251,4 +251,39 @@ func TestDataFrame_Drop(t *testing.T) {
 	if s.String() != seriesExpected {
 		t.Errorf("Expected:\n%v\nGot:\n%v", seriesExpected, s.String())
 	}
-}
\ No newline at end of file
+}
+
+func TestDataFrame_StringRunic(t *testing.T) {
+	expected := "   Runes\n0      a\n1      b"
+	df := New(series.New([]rune{'a', 'b'}, series.Runic, "Runes"))
+
+	if df.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, df.String())
+	}
+}
+
+func TestDataFrame_AsType(t *testing.T) {
+	df := New(
+		series.New([]string{"1", "2", "x"}, series.String, "A"),
+		series.New([]string{"1.5", "y", "3"}, series.String, "B"),
+		series.New([]int{1, 2, 3}, series.Int, "C"),
+	)
+	expected := "     A    B  C\n0    1  1.5  1\n1    2  NaN  2\n2  NaN    3  3"
+
+	result, err := df.AsType(map[string]series.Type{"A": series.Int, "B": series.Float})
+
+	if err == nil {
+		t.Errorf("Expected an error, got nil")
+	}
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+	if df.Column("A").Type() != series.String {
+		t.Errorf("Expected AsType to leave the original unchanged")
+	}
+
+	_, err = df.AsType(map[string]series.Type{"A": series.Int}, series.CastOptions{Errors: "coerce"})
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
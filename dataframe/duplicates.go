@@ -0,0 +1,86 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe/series"
+	"strings"
+)
+
+// duplicateKey builds a hashable key from the values of row i of the specified columns, where NA values are equal to
+// one another.
+func duplicateKey(columns []series.Series, i int) string {
+	var sb strings.Builder
+	for _, s := range columns {
+		if s.Elem(i).IsNA() {
+			sb.WriteString("NA;")
+		} else {
+			writeKey(&sb, s, i)
+		}
+	}
+	return sb.String()
+}
+
+// Duplicated returns a boolean series marking the rows of the DataFrame whose values in the subset columns repeat those
+// of another row, where NA values are equal to one another. A nil subset uses every column. keep is "first" (the
+// default) to mark every occurrence but the first, "last" to mark every occurrence but the last, or "none" to mark
+// every occurrence.
+func (df DataFrame) Duplicated(subset []string, keep ...string) series.Series {
+	if len(keep) > 1 {
+		panic("only one keep allowed")
+	}
+	if len(keep) == 0 {
+		keep = []string{"first"}
+	}
+
+	columns := df.columns
+	if subset != nil {
+		columns = make([]series.Series, len(subset))
+		for i, name := range subset {
+			columns[i] = df.columns[df.columnPosition(name)]
+		}
+	}
+
+	counts := make(map[string]int)
+	keys := make([]string, df.nrows)
+	for i := range keys {
+		keys[i] = duplicateKey(columns, i)
+		counts[keys[i]]++
+	}
+
+	duplicated := make([]bool, df.nrows)
+	switch keep[0] {
+	case "first":
+		seen := make(map[string]struct{})
+		for i, key := range keys {
+			_, duplicated[i] = seen[key]
+			seen[key] = struct{}{}
+		}
+	case "last":
+		seen := make(map[string]struct{})
+		for i := df.nrows - 1; i >= 0; i-- {
+			_, duplicated[i] = seen[keys[i]]
+			seen[keys[i]] = struct{}{}
+		}
+	case "none":
+		for i, key := range keys {
+			duplicated[i] = counts[key] > 1
+		}
+	default:
+		panic(fmt.Errorf("keep must be one of %v, but got %v", []string{"first", "last", "none"}, keep[0]))
+	}
+
+	return series.New(duplicated, series.Boolean, "Duplicated")
+}
+
+// DropDuplicates returns a new DataFrame without the rows marked by Duplicated, keeping the index of the remaining rows.
+func (df DataFrame) DropDuplicates(subset []string, keep ...string) DataFrame {
+	duplicated := df.Duplicated(subset, keep...)
+
+	var rows []int
+	for i := 0; i < df.nrows; i++ {
+		if !duplicated.Val(i).(bool) {
+			rows = append(rows, i)
+		}
+	}
+	return df.take(rows, df.allColumns())
+}
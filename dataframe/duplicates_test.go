@@ -0,0 +1,93 @@
+package dataframe
+
+import (
+	"github.com/chriso345/golab/dataframe/series"
+	"math"
+	"testing"
+)
+
+func TestDataFrame_Duplicated(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b", "a", "c", "a"}, series.String, "Key"),
+		series.New([]float64{1, math.NaN(), 1, 2, math.NaN()}, series.Float, "Floats"),
+	)
+
+	tests := []struct {
+		subset   []string
+		keep     string
+		expected string
+	}{
+		{nil, "first", "{Duplicated [false false true false false] bool}"},
+		{nil, "last", "{Duplicated [true false false false false] bool}"},
+		{nil, "none", "{Duplicated [true false true false false] bool}"},
+		{[]string{"Key"}, "first", "{Duplicated [false false true false true] bool}"},
+		{[]string{"Floats"}, "first", "{Duplicated [false false true false true] bool}"},
+	}
+
+	for _, test := range tests {
+		duplicated := df.Duplicated(test.subset, test.keep)
+		if duplicated.String() != test.expected {
+			t.Errorf("Expected:\n%v\nGot:\n%v", test.expected, duplicated.String())
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Duplicated to panic, but it did not")
+		}
+	}()
+
+	df.Duplicated(nil, "not a valid keep")
+}
+
+func TestDataFrame_Duplicated_Separators(t *testing.T) {
+	// Values containing the separator of the parts of a key must not collide
+	df := New(
+		series.New([]string{"a\x00b", "a"}, series.String, "First"),
+		series.New([]string{"c", "b\x00c"}, series.String, "Second"),
+	)
+
+	expected := "{Duplicated [false false] bool}"
+	if duplicated := df.Duplicated(nil); duplicated.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, duplicated.String())
+	}
+}
+
+func TestDataFrame_DropDuplicates(t *testing.T) {
+	expected := "   Key  Floats\n1    b     NaN\n3    c       2\n4    a     NaN"
+
+	df := New(
+		series.New([]string{"a", "b", "a", "c", "a"}, series.String, "Key"),
+		series.New([]float64{1, math.NaN(), 1, 2, math.NaN()}, series.Float, "Floats"),
+	)
+	result := df.DropDuplicates([]string{"Key"}, "last")
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestDataFrame_New_DuplicateNames(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected New to panic, but it did not")
+		}
+	}()
+
+	New(
+		series.New([]int{1, 2}, series.Int, "Integers"),
+		series.New([]int{3, 4}, series.Int, "Integers"),
+	)
+}
+
+func TestDataFrame_Append_DuplicateNames(t *testing.T) {
+	df := New(series.New([]int{1, 2}, series.Int, "Integers"))
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Append to panic, but it did not")
+		}
+	}()
+
+	df.Append(series.New([]int{3, 4}, series.Int, "Integers"))
+}
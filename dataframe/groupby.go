@@ -0,0 +1,421 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe/series"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// GroupBy is a collection of the rows of a DataFrame split into groups by the values of one or more key columns.
+// Groups are kept in order of first appearance, and rows with an NA key are dropped.
+type GroupBy struct {
+	df      DataFrame
+	keys    []string
+	groups  [][]int
+	asIndex bool
+}
+
+// Aggregation describes how the values of a group are reduced to a single value.
+type Aggregation struct {
+	// Name labels the resulting column when more than one aggregation is applied to a column
+	Name string
+	// Func reduces the non-NA values of a group to a single value, a nil result is stored as NA
+	Func func(s series.Series) any
+	// Type is the type of the resulting series, the type of the aggregated column is kept if empty
+	Type series.Type
+	// NumericOnly skips non-numeric columns when the aggregation is applied to every column
+	NumericOnly bool
+}
+
+var (
+	// AggSum sums the values of each group.
+	AggSum = Aggregation{Name: "sum", Type: series.Float, NumericOnly: true, Func: func(s series.Series) any {
+		return s.Sum()
+	}}
+
+	// AggMean averages the values of each group.
+	AggMean = Aggregation{Name: "mean", Type: series.Float, NumericOnly: true, Func: func(s series.Series) any {
+		if s.Len() == 0 {
+			return nil
+		}
+		return s.Mean()
+	}}
+
+	// AggCount counts the non-NA values of each group.
+	AggCount = Aggregation{Name: "count", Type: series.Int, Func: func(s series.Series) any {
+		return s.Len()
+	}}
+
+	// AggMin takes the smallest value of each group.
+	AggMin = Aggregation{Name: "min", Func: func(s series.Series) any {
+		return s.Min()
+	}}
+
+	// AggMax takes the largest value of each group.
+	AggMax = Aggregation{Name: "max", Func: func(s series.Series) any {
+		return s.Max()
+	}}
+
+	// AggStd takes the sample standard deviation of each group.
+	AggStd = Aggregation{Name: "std", Type: series.Float, NumericOnly: true, Func: func(s series.Series) any {
+		if s.Len() < 2 {
+			return nil
+		}
+		return s.Std()
+	}}
+
+	// AggMedian takes the median of each group.
+	AggMedian = AggQuantile(0.5)
+
+	// AggFirst takes the first non-NA value of each group.
+	AggFirst = Aggregation{Name: "first", Func: func(s series.Series) any {
+		if s.Len() == 0 {
+			return nil
+		}
+		return s.Val(0)
+	}}
+
+	// AggLast takes the last non-NA value of each group.
+	AggLast = Aggregation{Name: "last", Func: func(s series.Series) any {
+		if s.Len() == 0 {
+			return nil
+		}
+		return s.Val(s.Len() - 1)
+	}}
+
+	// AggNUnique counts the distinct non-NA values of each group.
+	AggNUnique = Aggregation{Name: "nunique", Type: series.Int, Func: func(s series.Series) any {
+		return s.NUnique()
+	}}
+)
+
+// AggQuantile takes the q-th quantile of each group.
+func AggQuantile(q float64) Aggregation {
+	name := fmt.Sprintf("q%v", q)
+	if q == 0.5 {
+		name = "median"
+	}
+
+	return Aggregation{Name: name, Type: series.Float, NumericOnly: true, Func: func(s series.Series) any {
+		if s.Len() == 0 {
+			return nil
+		}
+		return s.Quantile(q)
+	}}
+}
+
+// GroupBy groups the rows of the DataFrame by the values of the specified key columns.
+func (df DataFrame) GroupBy(keys ...string) GroupBy {
+	if len(keys) == 0 {
+		panic("no keys specified")
+	}
+
+	keyColumns := make([]series.Series, len(keys))
+	for i, key := range keys {
+		keyColumns[i] = df.columns[df.columnPosition(key)]
+	}
+
+	lookup := make(map[string]int)
+	var groups [][]int
+	for i := 0; i < df.nrows; i++ {
+		key, ok := rowKey(keyColumns, i)
+		if !ok {
+			continue
+		}
+
+		g, found := lookup[key]
+		if !found {
+			g = len(groups)
+			lookup[key] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+
+	return GroupBy{
+		df:     df,
+		keys:   keys,
+		groups: groups,
+	}
+}
+
+// Resample groups the rows of the DataFrame by the period of frequency freq containing the value of the datetime column
+// on, where freq is one of the frequencies accepted by series.DatetimeMethods.Floor. Groups are ordered by time, the
+// key column holds the start of each period, and periods without any rows are omitted.
+func (df DataFrame) Resample(on string, freq string) GroupBy {
+	position := df.columnPosition(on)
+
+	resampled := df.Copy()
+	resampled.columns[position] = df.columns[position].Dt().Floor(freq)
+	return resampled.SortValues([]string{on}).GroupBy(on)
+}
+
+// writeKey writes the non-NA value of row i of a series to a key, prefixed by the kind of the value and its length so
+// that distinct values never give the same key. Ints and floats share a kind and are written canonically, so equal
+// numbers match, as do strings and categories.
+func writeKey(sb *strings.Builder, s series.Series, i int) {
+	kind := s.Type()
+	switch kind {
+	case series.Int:
+		kind = series.Float
+	case series.Categorical:
+		kind = series.String
+	}
+
+	var v string
+	switch val := s.Val(i).(type) {
+	case int:
+		v = strconv.Itoa(val)
+	case float64:
+		v = numberKey(val)
+	default:
+		v = fmt.Sprint(val)
+	}
+	sb.WriteString(fmt.Sprintf("%v:%v:", kind, len(v)))
+	sb.WriteString(v)
+}
+
+// numberKey formats a float the same way as the int equal to it when there is one, and otherwise in its shortest
+// exact form, so that equal Int and Float values give the same key.
+func numberKey(f float64) string {
+	if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return strconv.FormatInt(int64(f), 10)
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// rowKey builds a hashable key from the values of row i of the specified columns. It returns false if any of the
+// values are NA.
+func rowKey(columns []series.Series, i int) (string, bool) {
+	var sb strings.Builder
+	for _, s := range columns {
+		if s.Elem(i).IsNA() {
+			return "", false
+		}
+		writeKey(&sb, s, i)
+	}
+	return sb.String(), true
+}
+
+// AsIndex returns a GroupBy which uses the group keys as the index of its results rather than as columns.
+// Multiple keys are joined into a single string label.
+func (g GroupBy) AsIndex() GroupBy {
+	g.asIndex = true
+	return g
+}
+
+// NGroups returns the number of groups.
+func (g GroupBy) NGroups() int {
+	return len(g.groups)
+}
+
+// isKey returns true if the column is one of the keys of the GroupBy.
+func (g GroupBy) isKey(name string) bool {
+	for _, key := range g.keys {
+		if key == name {
+			return true
+		}
+	}
+	return false
+}
+
+// result combines the key values of each group with the aggregated columns.
+func (g GroupBy) result(columns []series.Series) DataFrame {
+	firsts := make([]int, len(g.groups))
+	for i, rows := range g.groups {
+		firsts[i] = rows[0]
+	}
+
+	keyColumns := make([]series.Series, len(g.keys))
+	for i, key := range g.keys {
+		keyColumns[i] = g.df.columns[g.df.columnPosition(key)].Take(firsts...)
+	}
+
+	if !g.asIndex {
+		return New(append(keyColumns, columns...)...)
+	}
+
+	df := New(columns...)
+	if len(keyColumns) == 1 {
+		return df.SetIndex(keyColumns[0])
+	}
+
+	labels := make([]string, len(g.groups))
+	for i := range g.groups {
+		values := make([]string, len(keyColumns))
+		for j, s := range keyColumns {
+			values[j] = fmt.Sprint(s.Val(i))
+		}
+		labels[i] = strings.Join(values, ", ")
+	}
+	return df.SetIndex(series.New(labels, series.String, strings.Join(g.keys, ", ")))
+}
+
+// aggregate reduces the non-NA values of each group of s to a single value.
+func (g GroupBy) aggregate(s series.Series, agg Aggregation, name string) series.Series {
+	result := series.NewEmptySeriesLike(s, len(g.groups), name)
+	if agg.Type != "" {
+		result = series.NewEmptySeries(agg.Type, len(g.groups), name)
+	}
+	for i, rows := range g.groups {
+		result.Elem(i).Set(agg.Func(s.Take(rows...).DropNA()))
+	}
+	return result
+}
+
+// aggregateAll applies an aggregation to every non-key column.
+func (g GroupBy) aggregateAll(agg Aggregation) DataFrame {
+	var columns []series.Series
+	for _, s := range g.df.columns {
+		if g.isKey(s.Name) || (agg.NumericOnly && !s.IsNumeric()) {
+			continue
+		}
+		columns = append(columns, g.aggregate(s, agg, s.Name))
+	}
+	return g.result(columns)
+}
+
+// Agg applies the specified aggregations to each column. When more than one aggregation is applied to a column the
+// resulting columns are named by the column and aggregation name, such as "Floats_mean".
+func (g GroupBy) Agg(aggregations map[string][]Aggregation) DataFrame {
+	for name := range aggregations {
+		g.df.columnPosition(name)
+	}
+
+	var columns []series.Series
+	for _, s := range g.df.columns {
+		aggs := aggregations[s.Name]
+		for _, agg := range aggs {
+			name := s.Name
+			if len(aggs) > 1 {
+				name = fmt.Sprintf("%v_%v", s.Name, agg.Name)
+			}
+			columns = append(columns, g.aggregate(s, agg, name))
+		}
+	}
+	return g.result(columns)
+}
+
+// Sum returns the sum of each numeric column for each group.
+func (g GroupBy) Sum() DataFrame {
+	return g.aggregateAll(AggSum)
+}
+
+// Mean returns the mean of each numeric column for each group.
+func (g GroupBy) Mean() DataFrame {
+	return g.aggregateAll(AggMean)
+}
+
+// Count returns the number of non-NA values of each column for each group.
+func (g GroupBy) Count() DataFrame {
+	return g.aggregateAll(AggCount)
+}
+
+// Min returns the minimum of each column for each group.
+func (g GroupBy) Min() DataFrame {
+	return g.aggregateAll(AggMin)
+}
+
+// Max returns the maximum of each column for each group.
+func (g GroupBy) Max() DataFrame {
+	return g.aggregateAll(AggMax)
+}
+
+// Std returns the sample standard deviation of each numeric column for each group.
+func (g GroupBy) Std() DataFrame {
+	return g.aggregateAll(AggStd)
+}
+
+// Median returns the median of each numeric column for each group.
+func (g GroupBy) Median() DataFrame {
+	return g.aggregateAll(AggMedian)
+}
+
+// Quantile returns the q-th quantile of each numeric column for each group.
+func (g GroupBy) Quantile(q float64) DataFrame {
+	return g.aggregateAll(AggQuantile(q))
+}
+
+// First returns the first non-NA value of each column for each group.
+func (g GroupBy) First() DataFrame {
+	return g.aggregateAll(AggFirst)
+}
+
+// Last returns the last non-NA value of each column for each group.
+func (g GroupBy) Last() DataFrame {
+	return g.aggregateAll(AggLast)
+}
+
+// NUnique returns the number of distinct non-NA values of each column for each group.
+func (g GroupBy) NUnique() DataFrame {
+	return g.aggregateAll(AggNUnique)
+}
+
+// Apply calls f with the rows of each group, including the key columns, and concatenates the results row-wise.
+func (g GroupBy) Apply(f func(group DataFrame) DataFrame) DataFrame {
+	if len(g.groups) == 0 {
+		panic("no groups to apply to")
+	}
+
+	results := make([]DataFrame, len(g.groups))
+	for i, rows := range g.groups {
+		results[i] = f(g.df.take(rows, g.df.allColumns()))
+	}
+	return ConcatRows(results...)
+}
+
+// Transform calls f with the values of each non-key column of each group and returns a DataFrame aligned with the
+// rows of the original DataFrame. f must return either a series the same length as the group, or a single value
+// which is broadcast across the group. Rows with an NA key are NA in the result.
+func (g GroupBy) Transform(f func(s series.Series) series.Series) DataFrame {
+	var columns []series.Series
+	for _, s := range g.df.columns {
+		if g.isKey(s.Name) {
+			continue
+		}
+
+		var result series.Series
+		for i, rows := range g.groups {
+			transformed := f(s.Take(rows...))
+			if transformed.Len() != len(rows) && transformed.Len() != 1 {
+				panic(fmt.Errorf("transform returned length %v for a group of length %v", transformed.Len(), len(rows)))
+			}
+
+			if i == 0 {
+				result = series.NewEmptySeriesLike(transformed, g.df.nrows, s.Name)
+				for j := 0; j < g.df.nrows; j++ {
+					result.Elem(j).Set(nil)
+				}
+			}
+
+			for j, row := range rows {
+				if transformed.Len() == 1 {
+					copyElement(result.Elem(row), transformed, 0)
+				} else {
+					copyElement(result.Elem(row), transformed, j)
+				}
+			}
+		}
+
+		if len(g.groups) == 0 {
+			result = series.NewEmptySeriesLike(s, g.df.nrows, s.Name)
+			for j := 0; j < g.df.nrows; j++ {
+				result.Elem(j).Set(nil)
+			}
+		}
+		columns = append(columns, result)
+	}
+
+	return New(columns...).SetIndex(g.df.index)
+}
+
+// copyElement sets dst to the value of element i of src, preserving NA values.
+func copyElement(dst series.Element, src series.Series, i int) {
+	if src.Elem(i).IsNA() {
+		dst.Set(nil)
+		return
+	}
+	dst.Set(src.Val(i))
+}
@@ -0,0 +1,276 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe/series"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDataFrame_GroupBy(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+		series.New([]float64{1.5, 2.5, math.NaN(), 4.5, 5.5}, series.Float, "Floats"),
+	)
+
+	g := df.GroupBy("Key")
+
+	if g.NGroups() != 2 {
+		t.Errorf("Expected 2 groups, got %v", g.NGroups())
+	}
+	if fmt.Sprint(g.groups) != "[[0 2 4] [1 3]]" {
+		t.Errorf("Expected groups [[0 2 4] [1 3]], got %v", g.groups)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected GroupBy to panic, but it did not")
+		}
+	}()
+
+	df.GroupBy("Missing")
+}
+
+func TestGroupBy_Sum(t *testing.T) {
+	expected := "   Key  Integers  Floats\n0    a         9       7\n1    b         6       7"
+
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+		series.New([]float64{1.5, 2.5, math.NaN(), 4.5, 5.5}, series.Float, "Floats"),
+	)
+	result := df.GroupBy("Key").Sum()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestGroupBy_Mean(t *testing.T) {
+	expected := "   Key  Integers  Floats\n0    a         3     3.5\n1    b         3     3.5"
+
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+		series.New([]float64{1.5, 2.5, math.NaN(), 4.5, 5.5}, series.Float, "Floats"),
+	)
+	result := df.GroupBy("Key").Mean()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestGroupBy_Count(t *testing.T) {
+	expected := "   Key  Integers  Floats\n0    a         3       2\n1    b         2       2"
+
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+		series.New([]float64{1.5, 2.5, math.NaN(), 4.5, 5.5}, series.Float, "Floats"),
+	)
+	result := df.GroupBy("Key").Count()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestGroupBy_MinMax(t *testing.T) {
+	expected := "   Key  Integers  Floats\n0    a         1     1.5\n1    b         2     2.5"
+
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+		series.New([]float64{1.5, 2.5, math.NaN(), 4.5, 5.5}, series.Float, "Floats"),
+	)
+	result := df.GroupBy("Key").Min()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	expected = "   Key  Integers  Floats\n0    a         5     5.5\n1    b         4     4.5"
+
+	result = df.GroupBy("Key").Max()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestGroupBy_Std(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+		series.New([]float64{1.5, 2.5, math.NaN(), 4.5, 5.5}, series.Float, "Floats"),
+	)
+
+	result := df.GroupBy("Key").Std()
+
+	std := result.Column("Integers").Val(0).(float64)
+	if std != 2 {
+		t.Errorf("Expected std to be 2, got %v", std)
+	}
+
+	std = result.Column("Floats").Val(1).(float64)
+	if math.Abs(std-math.Sqrt(2)) > 1e-12 {
+		t.Errorf("Expected std to be %v, got %v", math.Sqrt(2), std)
+	}
+}
+
+func TestGroupBy_FirstLast(t *testing.T) {
+	expected := "   Key  Integers  Floats\n0    a         1     1.5\n1    b         2     2.5"
+
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+		series.New([]float64{1.5, 2.5, math.NaN(), 4.5, 5.5}, series.Float, "Floats"),
+	)
+	result := df.GroupBy("Key").First()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	expected = "   Key  Integers  Floats\n0    a         5     5.5\n1    b         4     4.5"
+
+	result = df.GroupBy("Key").Last()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestGroupBy_NUnique(t *testing.T) {
+	expected := "   Integers  Floats\na         3       2\nb         2       2"
+
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+		series.New([]float64{1.5, 2.5, math.NaN(), 4.5, 5.5}, series.Float, "Floats"),
+	)
+	result := df.GroupBy("Key").AsIndex().NUnique()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestGroupBy_Agg(t *testing.T) {
+	expected := "   Key  Integers_min  Integers_max  Integers_custom\n0    a             1             5               30\n1    b             2             4               20"
+
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+		series.New([]float64{1.5, 2.5, math.NaN(), 4.5, 5.5}, series.Float, "Floats"),
+	)
+	custom := Aggregation{Name: "custom", Type: series.Int, Func: func(s series.Series) any {
+		return s.Len() * 10
+	}}
+
+	result := df.GroupBy("Key").Agg(map[string][]Aggregation{
+		"Integers": {AggMin, AggMax, custom},
+	})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestGroupBy_MultipleKeys(t *testing.T) {
+	expected := "      Integers\na, 1         4\nb, 2         6\na, 2         5"
+
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Key1"),
+		series.New([]int{1, 2, 1, 2, 2}, series.Int, "Key2"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+	)
+	result := df.GroupBy("Key1", "Key2").AsIndex().Sum()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestGroupBy_Apply(t *testing.T) {
+	expected := "   Key  Integers  Floats\n0    a         1     1.5\n1    b         2     2.5"
+
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+		series.New([]float64{1.5, 2.5, math.NaN(), 4.5, 5.5}, series.Float, "Floats"),
+	)
+	result := df.GroupBy("Key").Apply(func(group DataFrame) DataFrame {
+		return group.Head(1)
+	})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestGroupBy_Transform(t *testing.T) {
+	expected := "   Integers\n0         9\n1         6\n2         9\n3         6\n4         9"
+
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+	)
+	result := df.GroupBy("Key").Transform(func(s series.Series) series.Series {
+		sum := 0
+		for i := 0; i < s.Len(); i++ {
+			sum += s.Val(i).(int)
+		}
+		return series.New([]int{sum}, series.Int, s.Name)
+	})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestDataFrame_Resample(t *testing.T) {
+	df := New(
+		series.New([]time.Time{
+			time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 1, 9, 15, 0, 0, time.UTC),
+			time.Date(2024, 1, 1, 9, 50, 0, 0, time.UTC),
+			time.Date(2024, 1, 1, 11, 5, 0, 0, time.UTC),
+		}, series.Datetime, "Time"),
+		series.New([]int{4, 1, 2, 3}, series.Int, "Integers"),
+	)
+	expected := "                            Time  Integers\n" +
+		"0  2024-01-01 00:00:00 +0000 UTC         6\n" +
+		"1  2024-01-02 00:00:00 +0000 UTC         4"
+
+	result := df.Resample("Time", "D").Sum()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	result = df.Resample("Time", "1h").Count()
+	if result.Column("Integers").String() != "{Integers [2 1 1] int}" {
+		t.Errorf("Expected:\n%v\nGot:\n%v", "{Integers [2 1 1] int}", result.Column("Integers").String())
+	}
+}
+
+func TestGroupBy_Categorical(t *testing.T) {
+	df := New(
+		series.NewCategorical([]string{"low", "high", "low"}, []string{"low", "high"}, "Level"),
+		series.NewCategorical([]string{"x", "y", "y"}, nil, "Category"),
+	)
+	expected := "   Level  Category\n0    low         y\n1   high         y"
+
+	result := df.GroupBy("Level").Max()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+	if result.Column("Category").Type() != series.Categorical {
+		t.Errorf("Expected:\n%v\nGot:\n%v", series.Categorical, result.Column("Category").Type())
+	}
+}
@@ -0,0 +1,134 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe/series"
+)
+
+// take returns a new DataFrame made up of the specified row and column positions, keeping the matching index labels.
+// An empty list of columns gives a DataFrame with the rows of the index and no columns.
+func (df DataFrame) take(rows []int, columns []int) DataFrame {
+	se := make([]series.Series, len(columns))
+	for i, j := range columns {
+		se[i] = df.columns[j].Take(rows...)
+	}
+
+	if len(se) > 0 {
+		if _, _, err := checkColumnDimensions(se...); err != nil {
+			panic(err)
+		}
+	}
+
+	return DataFrame{
+		index:   df.index.Take(rows...),
+		columns: se,
+		ncols:   len(se),
+		nrows:   len(rows),
+	}
+}
+
+// allRows returns the positions of every row of the DataFrame.
+func (df DataFrame) allRows() []int {
+	rows := make([]int, df.nrows)
+	for i := range rows {
+		rows[i] = i
+	}
+	return rows
+}
+
+// allColumns returns the positions of every column of the DataFrame.
+func (df DataFrame) allColumns() []int {
+	columns := make([]int, df.ncols)
+	for i := range columns {
+		columns[i] = i
+	}
+	return columns
+}
+
+// columnPosition returns the position of the column with the specified name.
+func (df DataFrame) columnPosition(name string) int {
+	for i, s := range df.columns {
+		if s.Name == name {
+			return i
+		}
+	}
+	panic(fmt.Errorf("column %v not found", name))
+}
+
+// Select returns a new DataFrame containing only the specified columns, in the order given.
+func (df DataFrame) Select(columns ...string) DataFrame {
+	if len(columns) == 0 {
+		panic("no columns specified")
+	}
+
+	positions := make([]int, len(columns))
+	for i, name := range columns {
+		positions[i] = df.columnPosition(name)
+	}
+
+	return df.take(df.allRows(), positions)
+}
+
+// Take returns a new DataFrame containing the rows at the specified positions, in the order given.
+func (df DataFrame) Take(rows ...int) DataFrame {
+	for _, row := range rows {
+		if row < 0 || row >= df.nrows {
+			panic(fmt.Errorf("index %v out of range", row))
+		}
+	}
+
+	return df.take(rows, df.allColumns())
+}
+
+// Filter returns a new DataFrame containing the rows where the boolean mask is true. NA values in the mask are
+// treated as false.
+func (df DataFrame) Filter(mask series.Series) DataFrame {
+	if mask.Len() != df.nrows {
+		panic(fmt.Errorf("mask length %v does not match DataFrame length %v", mask.Len(), df.nrows))
+	}
+
+	return df.take(mask.TruePositions(), df.allColumns())
+}
+
+// Loc returns a new DataFrame containing the rows whose index labels match the specified labels, in the order given.
+// Labels that appear more than once in the index select every matching row.
+func (df DataFrame) Loc(labels ...any) DataFrame {
+	positions := make(map[any][]int, df.nrows)
+	for i := 0; i < df.nrows; i++ {
+		positions[df.index.Val(i)] = append(positions[df.index.Val(i)], i)
+	}
+
+	var rows []int
+	for _, label := range labels {
+		p, ok := positions[label]
+		if !ok {
+			panic(fmt.Errorf("label %v not found in index", label))
+		}
+		rows = append(rows, p...)
+	}
+
+	return df.take(rows, df.allColumns())
+}
+
+// ILoc returns a new DataFrame containing rows rowStart to rowEnd and columns colStart to colEnd, where the end
+// positions are exclusive. Empty ranges, where the start equals the end, give no rows or no columns.
+func (df DataFrame) ILoc(rowStart, rowEnd, colStart, colEnd int) DataFrame {
+	if rowStart < 0 || rowEnd > df.nrows || rowStart > rowEnd {
+		panic(fmt.Errorf("row range [%v, %v) out of range", rowStart, rowEnd))
+	}
+	if colStart < 0 || colEnd > df.ncols || colStart > colEnd {
+		panic(fmt.Errorf("column range [%v, %v) out of range", colStart, colEnd))
+	}
+
+	rows := make([]int, rowEnd-rowStart)
+	for i := range rows {
+		rows[i] = rowStart + i
+	}
+
+	columns := make([]int, colEnd-colStart)
+	for i := range columns {
+		columns[i] = colStart + i
+	}
+
+	return df.take(rows, columns)
+}
@@ -0,0 +1,135 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe/series"
+	"testing"
+)
+
+func TestDataFrame_Select(t *testing.T) {
+	expected := "   Floats  Integers\n0     4.4         1\n1     5.5         2\n2     6.6         3"
+
+	df := New(
+		series.New([]int{1, 2, 3}, series.Int, "Integers"),
+		series.New([]float64{4.4, 5.5, 6.6}, series.Float, "Floats"),
+		series.New([]string{"a", "b", "c"}, series.String, "Strings"),
+	)
+	result := df.Select("Floats", "Integers")
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Select to panic, but it did not")
+		}
+	}()
+
+	df.Select("Missing")
+}
+
+func TestDataFrame_Take(t *testing.T) {
+	expected := "   Integers  Floats\n2         3     6.6\n0         1     4.4"
+
+	df := New(
+		series.New([]int{1, 2, 3}, series.Int, "Integers"),
+		series.New([]float64{4.4, 5.5, 6.6}, series.Float, "Floats"),
+	)
+	result := df.Take(2, 0)
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	// The original DataFrame should not be modified
+	if df.At(0, 0) != 1 {
+		t.Errorf("Expected original DataFrame to be unchanged, got %v", df.At(0, 0))
+	}
+}
+
+func TestDataFrame_Filter(t *testing.T) {
+	expected := "   Integers  Floats\n1         2     5.5\n3         4     7.7"
+
+	df := New(
+		series.New([]int{1, 2, 3, 4}, series.Int, "Integers"),
+		series.New([]float64{4.4, 5.5, 6.6, 7.7}, series.Float, "Floats"),
+	)
+	mask := series.New([]bool{false, true, false, true}, series.Boolean, "Mask")
+	result := df.Filter(mask)
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	rows, cols := df.Filter(series.New([]bool{false, false, false, false}, series.Boolean, "Mask")).Shape()
+	if rows != 0 || cols != 2 {
+		t.Errorf("Expected (0, 2), got (%v, %v)", rows, cols)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Filter to panic, but it did not")
+		}
+	}()
+
+	df.Filter(series.New([]int{1, 0, 1, 0}, series.Int, "Mask"))
+}
+
+func TestDataFrame_Loc(t *testing.T) {
+	expected := "   Integers  Floats\nc         3     6.6\na         1     4.4"
+
+	df := New(
+		series.New([]int{1, 2, 3}, series.Int, "Integers"),
+		series.New([]float64{4.4, 5.5, 6.6}, series.Float, "Floats"),
+	)
+	df = df.SetIndex(series.New([]string{"a", "b", "c"}, series.String, "Labels"))
+	result := df.Loc("c", "a")
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Loc to panic, but it did not")
+		}
+	}()
+
+	df.Loc("d")
+}
+
+func TestDataFrame_ILoc(t *testing.T) {
+	expected := "   Floats  Integers2\n1     5.5          8\n2     6.6          9"
+
+	df := New(
+		series.New([]int{1, 2, 3}, series.Int, "Integers"),
+		series.New([]float64{4.4, 5.5, 6.6}, series.Float, "Floats"),
+		series.New([]int{7, 8, 9}, series.Int, "Integers2"),
+	)
+	result := df.ILoc(1, 3, 1, 3)
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	// Empty row and column ranges are both allowed
+	if rows, cols := df.ILoc(2, 2, 0, 3).Shape(); rows != 0 || cols != 3 {
+		t.Errorf("Expected (0, 3), got (%v, %v)", rows, cols)
+	}
+	if rows, cols := df.ILoc(0, 3, 3, 3).Shape(); rows != 3 || cols != 0 {
+		t.Errorf("Expected (3, 0), got (%v, %v)", rows, cols)
+	}
+
+	for _, r := range [][]int{{0, 4, 0, 1}, {2, 1, 0, 1}, {0, 1, 0, 4}, {0, 1, 2, 1}, {-1, 1, 0, 1}} {
+		t.Run(fmt.Sprint(r), func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected ILoc to panic, but it did not")
+				}
+			}()
+
+			df.ILoc(r[0], r[1], r[2], r[3])
+		})
+	}
+}
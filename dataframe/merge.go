@@ -0,0 +1,183 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe/series"
+)
+
+// MergeSettings defines a struct that contains settings for merging two DataFrames, allows for optional settings
+type MergeSettings struct {
+	// How is one of "inner", "left", "right", "outer" or "cross"
+	How string
+	// On is the collection of key columns, defaulting to the columns shared by both DataFrames
+	On []string
+	// Suffixes are appended to clashing column names from the left and right DataFrames
+	Suffixes [2]string
+}
+
+var defaultMergeSettings = MergeSettings{
+	How:      "inner",
+	On:       nil,
+	Suffixes: [2]string{"_x", "_y"},
+}
+
+// Merge joins the DataFrame with another DataFrame on the values of one or more key columns. The result holds the key
+// columns followed by the remaining columns of the left and then right DataFrame, and has a reset index. Rows without
+// a match in the other DataFrame are filled with NA values, and NA keys never match.
+func (df DataFrame) Merge(right DataFrame, settings ...MergeSettings) DataFrame {
+	if len(settings) == 0 {
+		settings = append(settings, defaultMergeSettings)
+	} else if len(settings) > 1 {
+		panic(fmt.Errorf("only one settings struct allowed"))
+	}
+
+	s := settings[0]
+	if s.How == "" {
+		s.How = defaultMergeSettings.How
+	}
+	if s.Suffixes == [2]string{} {
+		s.Suffixes = defaultMergeSettings.Suffixes
+	}
+
+	on := s.On
+	if s.How == "cross" {
+		if len(on) != 0 {
+			panic(fmt.Errorf("cross merge does not use key columns, but got %v", on))
+		}
+	} else if len(on) == 0 {
+		for _, name := range df.Names() {
+			for _, other := range right.Names() {
+				if name == other {
+					on = append(on, name)
+				}
+			}
+		}
+		if len(on) == 0 {
+			panic(fmt.Errorf("no common columns to merge on"))
+		}
+	}
+
+	var leftRows, rightRows []int
+	switch s.How {
+	case "inner", "left", "outer":
+		leftRows, rightRows = hashJoin(df, right, on, s.How != "inner")
+		if s.How == "outer" {
+			matched := make([]bool, right.nrows)
+			for _, r := range rightRows {
+				if r != -1 {
+					matched[r] = true
+				}
+			}
+			for r, ok := range matched {
+				if !ok {
+					leftRows = append(leftRows, -1)
+					rightRows = append(rightRows, r)
+				}
+			}
+		}
+	case "right":
+		rightRows, leftRows = hashJoin(right, df, on, true)
+	case "cross":
+		for l := 0; l < df.nrows; l++ {
+			for r := 0; r < right.nrows; r++ {
+				leftRows = append(leftRows, l)
+				rightRows = append(rightRows, r)
+			}
+		}
+	default:
+		panic(fmt.Errorf("how must be one of %v, but got %v", []string{"inner", "left", "right", "outer", "cross"}, s.How))
+	}
+
+	isKey := func(name string) bool {
+		for _, key := range on {
+			if key == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	var columns []series.Series
+	for _, key := range on {
+		column := df.columns[df.columnPosition(key)].Take(leftRows...)
+		rightColumn := right.columns[right.columnPosition(key)]
+		for i, l := range leftRows {
+			if l == -1 {
+				copyElement(column.Elem(i), rightColumn, rightRows[i])
+			}
+		}
+		columns = append(columns, column)
+	}
+
+	clashes := func(name string, other DataFrame) bool {
+		for _, s := range other.columns {
+			if s.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, c := range df.columns {
+		if isKey(c.Name) {
+			continue
+		}
+		column := c.Take(leftRows...)
+		if clashes(c.Name, right) {
+			column.Name = c.Name + s.Suffixes[0]
+		}
+		columns = append(columns, column)
+	}
+
+	for _, c := range right.columns {
+		if isKey(c.Name) {
+			continue
+		}
+		column := c.Take(rightRows...)
+		if clashes(c.Name, df) {
+			column.Name = c.Name + s.Suffixes[1]
+		}
+		columns = append(columns, column)
+	}
+
+	return New(columns...)
+}
+
+// hashJoin matches each row of left to the rows of right sharing the same key values, returning the matched row
+// positions in order of the left rows. Unmatched left rows are kept with a right position of -1 if keep is true.
+func hashJoin(left, right DataFrame, on []string, keep bool) (leftRows []int, rightRows []int) {
+	leftKeys := make([]series.Series, len(on))
+	rightKeys := make([]series.Series, len(on))
+	for i, key := range on {
+		leftKeys[i] = left.columns[left.columnPosition(key)]
+		rightKeys[i] = right.columns[right.columnPosition(key)]
+	}
+
+	table := make(map[string][]int)
+	for r := 0; r < right.nrows; r++ {
+		if key, ok := rowKey(rightKeys, r); ok {
+			table[key] = append(table[key], r)
+		}
+	}
+
+	for l := 0; l < left.nrows; l++ {
+		var matches []int
+		if key, ok := rowKey(leftKeys, l); ok {
+			matches = table[key]
+		}
+
+		if len(matches) == 0 {
+			if keep {
+				leftRows = append(leftRows, l)
+				rightRows = append(rightRows, -1)
+			}
+			continue
+		}
+
+		for _, r := range matches {
+			leftRows = append(leftRows, l)
+			rightRows = append(rightRows, r)
+		}
+	}
+	return
+}
@@ -0,0 +1,163 @@
+package dataframe
+
+import (
+	"github.com/chriso345/golab/dataframe/series"
+	"testing"
+)
+
+func TestDataFrame_MergeInner(t *testing.T) {
+	expected := "   Key  Value_x  Value_y\n0    2        b        w\n1    3        c        x\n2    3        c        y"
+
+	left := New(
+		series.New([]int{1, 2, 3}, series.Int, "Key"),
+		series.New([]string{"a", "b", "c"}, series.String, "Value"),
+	)
+	right := New(
+		series.New([]int{2, 3, 3, 4}, series.Int, "Key"),
+		series.New([]string{"w", "x", "y", "z"}, series.String, "Value"),
+	)
+	result := left.Merge(right, MergeSettings{On: []string{"Key"}})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestDataFrame_MergeLeft(t *testing.T) {
+	expected := "   Key  Value_l  Value_r\n0    1        a      NaN\n1    2        b        w\n2    3        c        x\n3    3        c        y"
+
+	left := New(
+		series.New([]int{1, 2, 3}, series.Int, "Key"),
+		series.New([]string{"a", "b", "c"}, series.String, "Value"),
+	)
+	right := New(
+		series.New([]int{2, 3, 3, 4}, series.Int, "Key"),
+		series.New([]string{"w", "x", "y", "z"}, series.String, "Value"),
+	)
+	result := left.Merge(right, MergeSettings{How: "left", On: []string{"Key"}, Suffixes: [2]string{"_l", "_r"}})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestDataFrame_MergeRight(t *testing.T) {
+	expected := "   Key  Value_x  Value_y\n0    2        b        w\n1    3        c        x\n2    3        c        y\n3    4      NaN        z"
+
+	left := New(
+		series.New([]int{1, 2, 3}, series.Int, "Key"),
+		series.New([]string{"a", "b", "c"}, series.String, "Value"),
+	)
+	right := New(
+		series.New([]int{2, 3, 3, 4}, series.Int, "Key"),
+		series.New([]string{"w", "x", "y", "z"}, series.String, "Value"),
+	)
+	result := left.Merge(right, MergeSettings{How: "right", On: []string{"Key"}})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestDataFrame_MergeOuter(t *testing.T) {
+	expected := "   Key  Value_x  Value_y\n0    1        a      NaN\n1    2        b        w\n2    3        c        x\n3    3        c        y\n4    4      NaN        z"
+
+	left := New(
+		series.New([]int{1, 2, 3}, series.Int, "Key"),
+		series.New([]string{"a", "b", "c"}, series.String, "Value"),
+	)
+	right := New(
+		series.New([]int{2, 3, 3, 4}, series.Int, "Key"),
+		series.New([]string{"w", "x", "y", "z"}, series.String, "Value"),
+	)
+	result := left.Merge(right, MergeSettings{How: "outer", On: []string{"Key"}})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestDataFrame_MergeCross(t *testing.T) {
+	left := New(series.New([]int{1, 2}, series.Int, "A"))
+	right := New(series.New([]string{"x", "y"}, series.String, "B"))
+	expected := "   A  B\n0  1  x\n1  1  y\n2  2  x\n3  2  y"
+
+	result := left.Merge(right, MergeSettings{How: "cross"})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestDataFrame_MergeMultipleKeys(t *testing.T) {
+	left := New(
+		series.New([]string{"a", "a", "b"}, series.String, "Key1"),
+		series.New([]int{1, 2, 1}, series.Int, "Key2"),
+		series.New([]float64{1.1, 2.2, 3.3}, series.Float, "Left"),
+	)
+	right := New(
+		series.New([]string{"a", "b", "b"}, series.String, "Key1"),
+		series.New([]int{2, 1, 2}, series.Int, "Key2"),
+		series.New([]float64{4.4, 5.5, 6.6}, series.Float, "Right"),
+	)
+	expected := "   Key1  Key2  Left  Right\n0     a     2   2.2    4.4\n1     b     1   3.3    5.5"
+
+	result := left.Merge(right)
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Merge to panic, but it did not")
+		}
+	}()
+
+	left.Merge(right, MergeSettings{How: "not a valid join"})
+}
+
+func TestDataFrame_MergeKeyTypes(t *testing.T) {
+	left := New(
+		series.New([]int{1, 2}, series.Int, "Key"),
+		series.New([]string{"a", "b"}, series.String, "Left"),
+	)
+
+	// String keys do not match int keys with the same text
+	texts := New(
+		series.New([]string{"1", "2"}, series.String, "Key"),
+		series.New([]string{"x", "y"}, series.String, "Right"),
+	)
+	if result := left.Merge(texts, MergeSettings{On: []string{"Key"}}); result.Column("Right").Len() != 0 {
+		t.Errorf("Expected no matches between string and int keys, got %v", result.Column("Right").String())
+	}
+
+	// Float keys match int keys with the same value
+	floats := New(
+		series.New([]float64{2, 3}, series.Float, "Key"),
+		series.New([]string{"x", "y"}, series.String, "Right"),
+	)
+	expected := "{Right [x] string}"
+	if result := left.Merge(floats, MergeSettings{On: []string{"Key"}}); result.Column("Right").String() != expected {
+		t.Errorf("Expected %v, got %v", expected, result.Column("Right").String())
+	}
+}
+
+func TestDataFrame_MergeLargeNumericKeys(t *testing.T) {
+	expected := "{Right [x y] string}"
+
+	// Large floats which fmt writes with an exponent match the equal ints
+	left := New(
+		series.New([]int{100000000, 4000000000000000000}, series.Int, "Key"),
+		series.New([]string{"a", "b"}, series.String, "Left"),
+	)
+	right := New(
+		series.New([]float64{1e8, 4e18, 1e21}, series.Float, "Key"),
+		series.New([]string{"x", "y", "z"}, series.String, "Right"),
+	)
+	result := left.Merge(right, MergeSettings{On: []string{"Key"}})
+
+	if result.Column("Right").String() != expected {
+		t.Errorf("Expected %v, got %v", expected, result.Column("Right").String())
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/chriso345/golab/dataframe/series"
 	"io"
 	"os"
+	"time"
 )
 
 // CSVSettings defines a struct that contains settings for reading a CSV file, allows for optional settings
@@ -15,6 +16,12 @@ type CSVSettings struct {
 	Separator rune
 	IndexColumn string
 	SkipRows []int
+	// DatetimeColumns are parsed with DatetimeLayout, which defaults to time.RFC3339, in Location, which defaults to UTC
+	DatetimeColumns []string
+	DatetimeLayout string
+	Location *time.Location
+	// CategoricalColumns are converted to categoricals of their sorted unique values
+	CategoricalColumns []string
 }
 
 var defaultCSVSettings = CSVSettings{
@@ -91,6 +98,28 @@ func FromCSV(path string, settings ...CSVSettings) *DataFrame {
 		}
 	}
 
+	layout := settings[0].DatetimeLayout
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	location := settings[0].Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	for jdx, s := range se {
+		for _, name := range settings[0].DatetimeColumns {
+			if s.Name == name {
+				se[jdx] = s.ToDatetime(layout, location)
+			}
+		}
+		for _, name := range settings[0].CategoricalColumns {
+			if s.Name == name {
+				se[jdx] = s.ToCategorical()
+			}
+		}
+	}
+
 	df := New(se...)
 	return &df
 }
@@ -1,6 +1,7 @@
 package dataframe
 
 import (
+	"github.com/chriso345/golab/dataframe/series"
 	"testing"
 )
 
@@ -24,3 +25,29 @@ func TestParseSQL(t *testing.T) {
 	panic("Test not implemented")
 }
 
+func TestFromCSV_DatetimeCategorical(t *testing.T) {
+	df := FromCSV("dataframe_test/events.csv", CSVSettings{
+		Header:             true,
+		Separator:          ',',
+		DatetimeColumns:    []string{"time"},
+		DatetimeLayout:     "2006-01-02 15:04",
+		CategoricalColumns: []string{"level"},
+	})
+
+	if df.Column("time").Type() != series.Datetime {
+		t.Errorf("Expected:\n%v\nGot:\n%v", series.Datetime, df.Column("time").Type())
+	}
+	if df.Column("level").Type() != series.Categorical {
+		t.Errorf("Expected:\n%v\nGot:\n%v", series.Categorical, df.Column("level").Type())
+	}
+
+	expected := "{time [9 9 11 8] int}"
+	if hours := df.Column("time").Dt().Hour(); hours.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, hours.String())
+	}
+
+	expected = "{level [1 0 1 2] int}"
+	if codes := df.Column("level").Codes(); codes.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, codes.String())
+	}
+}
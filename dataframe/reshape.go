@@ -0,0 +1,260 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe/series"
+)
+
+// uniquePositions returns the position of the first appearance of each distinct non-NA value of s, and the position
+// of that distinct value for every element of s, which is -1 for NA elements.
+func uniquePositions(s series.Series) (firsts []int, codes []int) {
+	lookup := make(map[any]int)
+	codes = make([]int, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		if s.Elem(i).IsNA() {
+			codes[i] = -1
+			continue
+		}
+
+		code, ok := lookup[s.Val(i)]
+		if !ok {
+			code = len(firsts)
+			lookup[s.Val(i)] = code
+			firsts = append(firsts, i)
+		}
+		codes[i] = code
+	}
+	return
+}
+
+// Pivot reshapes the DataFrame so that each distinct value of the index column becomes a row and each distinct value
+// of the columns column becomes a column holding the matching values. Combinations which do not appear are NA, and
+// each combination may only appear once.
+func (df DataFrame) Pivot(index, columns, values string) DataFrame {
+	indexColumn := df.columns[df.columnPosition(index)]
+	columnsColumn := df.columns[df.columnPosition(columns)]
+	valuesColumn := df.columns[df.columnPosition(values)]
+
+	rowFirsts, rowCodes := uniquePositions(indexColumn)
+	colFirsts, colCodes := uniquePositions(columnsColumn)
+
+	cells := make([][]int, len(colFirsts))
+	for j := range cells {
+		cells[j] = make([]int, len(rowFirsts))
+		for i := range cells[j] {
+			cells[j][i] = -1
+		}
+	}
+
+	for k := 0; k < df.nrows; k++ {
+		i, j := rowCodes[k], colCodes[k]
+		if i == -1 || j == -1 {
+			continue
+		}
+		if cells[j][i] != -1 {
+			panic(fmt.Errorf("duplicate entry for index %v and column %v", indexColumn.Val(k), columnsColumn.Val(k)))
+		}
+		cells[j][i] = k
+	}
+
+	result := make([]series.Series, len(colFirsts))
+	for j, first := range colFirsts {
+		result[j] = valuesColumn.Take(cells[j]...)
+		result[j].Name = fmt.Sprint(columnsColumn.Val(first))
+	}
+
+	return New(result...).SetIndex(indexColumn.Take(rowFirsts...))
+}
+
+// PivotTable reshapes the DataFrame like Pivot, aggregating the values of repeated combinations of index and columns.
+// Combinations which do not appear are set to fillValue, or left as NA if fillValue is nil.
+func (df DataFrame) PivotTable(index, columns, values string, agg Aggregation, fillValue any) DataFrame {
+	grouped := df.GroupBy(index, columns).Agg(map[string][]Aggregation{values: {agg}})
+	result := grouped.Pivot(index, columns, values)
+
+	if fillValue != nil {
+		for _, s := range result.columns {
+			for i := 0; i < s.Len(); i++ {
+				if s.Elem(i).IsNA() {
+					s.Elem(i).Set(fillValue)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Melt reshapes the DataFrame from wide to long format. Each value column is unpivoted into rows holding the id
+// columns, the name of the value column and its value. If no value columns are given, every non-id column is used.
+// The variable and value columns default to the names "variable" and "value".
+func (df DataFrame) Melt(idVars []string, valueVars []string, varName, valueName string) DataFrame {
+	if varName == "" {
+		varName = "variable"
+	}
+	if valueName == "" {
+		valueName = "value"
+	}
+
+	if len(valueVars) == 0 {
+		for _, name := range df.Names() {
+			isID := false
+			for _, id := range idVars {
+				isID = isID || id == name
+			}
+			if !isID {
+				valueVars = append(valueVars, name)
+			}
+		}
+	}
+	if len(valueVars) == 0 {
+		panic(fmt.Errorf("no value columns to melt"))
+	}
+
+	n := df.nrows * len(valueVars)
+	rows := make([]int, n)
+	names := make([]string, n)
+	types := make([]series.Type, len(valueVars))
+	values := make([]series.Series, len(valueVars))
+	for k, name := range valueVars {
+		values[k] = df.columns[df.columnPosition(name)]
+		types[k] = values[k].Type()
+		for i := 0; i < df.nrows; i++ {
+			rows[k*df.nrows+i] = i
+			names[k*df.nrows+i] = name
+		}
+	}
+
+	var result []series.Series
+	for _, id := range idVars {
+		result = append(result, df.columns[df.columnPosition(id)].Take(rows...))
+	}
+
+	present := make([]bool, len(valueVars))
+	lengths := make([]int, len(valueVars))
+	for k := range valueVars {
+		present[k] = true
+		lengths[k] = df.nrows
+	}
+
+	result = append(result,
+		series.New(names, series.String, varName),
+		stack(valueName, commonType(types...), values, present, lengths),
+	)
+	return New(result...)
+}
+
+// Stack reshapes the DataFrame into long format row by row, giving a DataFrame holding the index label, column name
+// and value of every cell.
+func (df DataFrame) Stack() DataFrame {
+	n := df.nrows * df.ncols
+	rows := make([]int, n)
+	names := make([]string, n)
+	types := make([]series.Type, df.ncols)
+	for j, s := range df.columns {
+		types[j] = s.Type()
+	}
+
+	present := make([]bool, df.ncols)
+	for j := range present {
+		present[j] = true
+	}
+
+	values := newEmptyCommon(commonType(types...), df.columns, present, n, "value")
+	for i := 0; i < df.nrows; i++ {
+		for j, s := range df.columns {
+			rows[i*df.ncols+j] = i
+			names[i*df.ncols+j] = s.Name
+			copyElement(values.Elem(i*df.ncols+j), s, i)
+		}
+	}
+
+	return New(
+		df.index.Take(rows...),
+		series.New(names, series.String, "variable"),
+		values,
+	)
+}
+
+// Crosstab counts the occurrences of each combination of the values of a and b, giving a DataFrame with a row for each
+// distinct value of a and a column for each distinct value of b. The counts can be normalized over "all" values, or
+// over each row with "index" or each column with "columns", or left as counts with "".
+func Crosstab(a, b series.Series, normalize string) DataFrame {
+	if a.Len() != b.Len() {
+		panic(fmt.Errorf("series lengths %v and %v must be equal", a.Len(), b.Len()))
+	}
+
+	normalizeStrings := []string{"", "all", "index", "columns"}
+	valid := false
+	for _, n := range normalizeStrings {
+		valid = valid || n == normalize
+	}
+	if !valid {
+		panic(fmt.Errorf("normalize must be one of %v, but got %v", normalizeStrings, normalize))
+	}
+
+	rowFirsts, rowCodes := uniquePositions(a)
+	colFirsts, colCodes := uniquePositions(b)
+
+	counts := make([][]int, len(colFirsts))
+	for j := range counts {
+		counts[j] = make([]int, len(rowFirsts))
+	}
+
+	total := 0
+	rowTotals := make([]int, len(rowFirsts))
+	colTotals := make([]int, len(colFirsts))
+	for k := 0; k < a.Len(); k++ {
+		i, j := rowCodes[k], colCodes[k]
+		if i == -1 || j == -1 {
+			continue
+		}
+		counts[j][i]++
+		rowTotals[i]++
+		colTotals[j]++
+		total++
+	}
+
+	columns := make([]series.Series, len(colFirsts))
+	for j, first := range colFirsts {
+		name := fmt.Sprint(b.Val(first))
+		if normalize == "" {
+			columns[j] = series.New(counts[j], series.Int, name)
+			continue
+		}
+
+		proportions := make([]float64, len(rowFirsts))
+		for i, c := range counts[j] {
+			switch normalize {
+			case "all":
+				proportions[i] = float64(c) / float64(total)
+			case "index":
+				proportions[i] = float64(c) / float64(rowTotals[i])
+			case "columns":
+				proportions[i] = float64(c) / float64(colTotals[j])
+			}
+		}
+		columns[j] = series.New(proportions, series.Float, name)
+	}
+
+	return New(columns...).SetIndex(a.Take(rowFirsts...))
+}
+
+// Transpose swaps the rows and columns of a numeric DataFrame. Each row becomes a float column named by its index
+// label, and the column names become the index.
+func (df DataFrame) Transpose() DataFrame {
+	for _, s := range df.columns {
+		if !s.IsNumeric() {
+			panic(fmt.Errorf("cannot transpose non-numeric column %v", s.Name))
+		}
+	}
+
+	columns := make([]series.Series, df.nrows)
+	for i := range columns {
+		columns[i] = series.NewEmptySeries(series.Float, df.ncols, fmt.Sprint(df.index.Val(i)))
+		for j, s := range df.columns {
+			copyElement(columns[i].Elem(j), s, i)
+		}
+	}
+
+	return New(columns...).SetIndex(series.New(df.Names(), series.String, "Index"))
+}
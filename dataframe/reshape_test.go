@@ -0,0 +1,139 @@
+package dataframe
+
+import (
+	"github.com/chriso345/golab/dataframe/series"
+	"testing"
+)
+
+func TestDataFrame_Pivot(t *testing.T) {
+	expected := "   a    b\nx  1    2\ny  3  NaN"
+
+	df := New(
+		series.New([]string{"x", "x", "y"}, series.String, "Row"),
+		series.New([]string{"a", "b", "a"}, series.String, "Column"),
+		series.New([]int{1, 2, 3}, series.Int, "Value"),
+	)
+	result := df.Pivot("Row", "Column", "Value")
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Pivot to panic, but it did not")
+		}
+	}()
+
+	// The row x and column a appear twice
+	duplicated := New(
+		series.New([]string{"x", "x", "x"}, series.String, "Row"),
+		series.New([]string{"a", "b", "a"}, series.String, "Column"),
+		series.New([]int{1, 2, 3}, series.Int, "Value"),
+	)
+	duplicated.Pivot("Row", "Column", "Value")
+}
+
+func TestDataFrame_PivotTable(t *testing.T) {
+	expected := "   a  b\nx  6  2\ny  3  0"
+
+	df := New(
+		series.New([]string{"x", "x", "y", "x"}, series.String, "Row"),
+		series.New([]string{"a", "b", "a", "a"}, series.String, "Column"),
+		series.New([]int{1, 2, 3, 5}, series.Int, "Value"),
+	)
+	result := df.PivotTable("Row", "Column", "Value", AggSum, 0)
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestDataFrame_Melt(t *testing.T) {
+	expected := "   Id  variable  value\n0   1         A    1.5\n1   2         A    2.5\n2   1         B      3\n3   2         B      4"
+
+	df := New(
+		series.New([]int{1, 2}, series.Int, "Id"),
+		series.New([]float64{1.5, 2.5}, series.Float, "A"),
+		series.New([]int{3, 4}, series.Int, "B"),
+	)
+	result := df.Melt([]string{"Id"}, nil, "", "")
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestDataFrame_Stack(t *testing.T) {
+	expected := "   Index  variable  value\n0      0         A      1\n1      0         B      3\n2      1         A      2\n3      1         B      4"
+
+	df := New(
+		series.New([]int{1, 2}, series.Int, "A"),
+		series.New([]int{3, 4}, series.Int, "B"),
+	)
+	result := df.Stack()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestCrosstab(t *testing.T) {
+	expected := "   a  b\nx  2  1\ny  1  1"
+
+	df := New(
+		series.New([]string{"x", "x", "y", "y", "x"}, series.String, "Row"),
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "Column"),
+	)
+	result := Crosstab(*df.Column("Row"), *df.Column("Column"), "")
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	result = Crosstab(*df.Column("Row"), *df.Column("Column"), "index")
+
+	if result.At(1, 0) != 0.5 {
+		t.Errorf("Expected 0.5, got %v", result.At(1, 0))
+	}
+
+	result = Crosstab(*df.Column("Row"), *df.Column("Column"), "all")
+
+	if result.At(0, 0) != 0.4 {
+		t.Errorf("Expected 0.4, got %v", result.At(0, 0))
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Crosstab to panic, but it did not")
+		}
+	}()
+
+	Crosstab(*df.Column("Row"), *df.Column("Column"), "not a valid normalization")
+}
+
+func TestDataFrame_Transpose(t *testing.T) {
+	expected := "            0    1\nIntegers    1    2\n  Floats  4.4  5.5"
+
+	df := New(
+		series.New([]int{1, 2}, series.Int, "Integers"),
+		series.New([]float64{4.4, 5.5}, series.Float, "Floats"),
+	)
+	result := df.Transpose()
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Transpose to panic, but it did not")
+		}
+	}()
+
+	mixed := New(
+		series.New([]string{"x", "y"}, series.String, "Row"),
+		series.New([]int{1, 2}, series.Int, "Value"),
+	)
+	mixed.Transpose()
+}
@@ -0,0 +1,203 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CastOptions defines a struct that contains settings for casting a series to another type, allows for optional
+// settings
+type CastOptions struct {
+	// Errors is either "raise" (the default), where values which cannot be cast exactly give a CastError, or
+	// "coerce", where they become NA
+	Errors string
+	// DecimalSeparator separates the integer and fractional parts of floats in strings, defaults to '.'
+	DecimalSeparator rune
+	// ThousandsSeparator groups the digits of numbers in strings, and is removed before parsing
+	ThousandsSeparator rune
+	// DatetimeLayout is used to parse and format datetimes in strings, defaults to time.RFC3339
+	DatetimeLayout string
+}
+
+var defaultCastOptions = CastOptions{
+	Errors:           "raise",
+	DecimalSeparator: '.',
+	DatetimeLayout:   time.RFC3339,
+}
+
+// CastError is returned when values of a series cannot be cast to another type, and records the rows which failed
+type CastError struct {
+	Name   string
+	From   Type
+	To     Type
+	Rows   []int
+	Values []any
+}
+
+// Error is the error implementation for CastError
+func (e *CastError) Error() string {
+	return fmt.Sprintf("cannot cast series %v from %v to %v at rows %v with values %v", e.Name, e.From, e.To, e.Rows,
+		e.Values)
+}
+
+// AsType returns a copy of the series cast to type t. NA values stay NA. Values which cannot be cast exactly, such
+// as a float with a fractional part cast to an int or a string which cannot be parsed, give a CastError listing
+// every such row unless the options coerce them to NA
+func (s Series) AsType(t Type, options ...CastOptions) (Series, error) {
+	if len(options) > 1 {
+		panic(fmt.Errorf("only one options struct allowed"))
+	}
+	if len(options) == 0 {
+		options = append(options, defaultCastOptions)
+	}
+
+	opts := options[0]
+	switch opts.Errors {
+	case "":
+		opts.Errors = defaultCastOptions.Errors
+	case "raise", "coerce":
+	default:
+		panic(fmt.Errorf("errors must be one of %v, but got %v", []string{"raise", "coerce"}, opts.Errors))
+	}
+	if opts.DecimalSeparator == 0 {
+		opts.DecimalSeparator = defaultCastOptions.DecimalSeparator
+	}
+	if opts.DatetimeLayout == "" {
+		opts.DatetimeLayout = defaultCastOptions.DatetimeLayout
+	}
+
+	if s.t == t {
+		return s.Copy(), nil
+	}
+
+	// Categoricals are cast through their string values
+	target := t
+	if t == Categorical {
+		target = String
+	}
+
+	result := NewEmptySeries(target, s.Len(), s.Name)
+	castErr := &CastError{Name: s.Name, From: s.t, To: t}
+	for i := 0; i < s.Len(); i++ {
+		if s.Elem(i).IsNA() {
+			result.Elem(i).Set(nil)
+			continue
+		}
+
+		v, ok := castValue(s.Val(i), target, opts)
+		if !ok {
+			castErr.Rows = append(castErr.Rows, i)
+			castErr.Values = append(castErr.Values, s.Val(i))
+			result.Elem(i).Set(nil)
+			continue
+		}
+		result.Elem(i).Set(v)
+	}
+
+	if t == Categorical {
+		result = result.ToCategorical()
+	}
+
+	if castErr.Rows != nil && opts.Errors == "raise" {
+		return result, castErr
+	}
+	return result, nil
+}
+
+// castValue converts a non-NA value to a value of type t, returning false if it cannot be converted exactly
+func castValue(v any, t Type, opts CastOptions) (any, bool) {
+	switch t {
+	case Int:
+		switch v_ := v.(type) {
+		case int:
+			return v_, true
+		case float64:
+			return int(v_), v_ == math.Trunc(v_) && math.Abs(v_) < math.MaxInt64
+		case bool:
+			return int(ToFloat(v_)), true
+		case string:
+			i, err := strconv.Atoi(strings.TrimSpace(removeSeparator(v_, opts.ThousandsSeparator)))
+			return i, err == nil
+		}
+	case Float:
+		switch v_ := v.(type) {
+		case int, float64, bool:
+			return ToFloat(v_), true
+		case string:
+			f, err := parseFloat(v_, opts)
+			return f, err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+		}
+	case Boolean:
+		switch v_ := v.(type) {
+		case bool:
+			return v_, true
+		case int, float64:
+			f := ToFloat(v_)
+			return f != 0, f == 0 || f == 1
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v_))
+			return b, err == nil
+		}
+	case String:
+		switch v_ := v.(type) {
+		case int:
+			return strconv.Itoa(v_), true
+		case float64:
+			f := strconv.FormatFloat(v_, 'f', -1, 64)
+			return strings.Replace(f, ".", string(opts.DecimalSeparator), 1), true
+		case bool:
+			return strconv.FormatBool(v_), true
+		case string:
+			return v_, true
+		case rune:
+			return string(v_), true
+		case time.Time:
+			return v_.Format(opts.DatetimeLayout), true
+		}
+	case Runic:
+		switch v_ := v.(type) {
+		case rune:
+			return v_, true
+		case string:
+			runes := []rune(v_)
+			if len(runes) != 1 {
+				return nil, false
+			}
+			return runes[0], true
+		}
+	case Datetime:
+		switch v_ := v.(type) {
+		case time.Time:
+			return v_, true
+		case string:
+			d, err := time.Parse(opts.DatetimeLayout, strings.TrimSpace(v_))
+			return d, err == nil
+		}
+	default:
+		panic(fmt.Errorf("type %v not supported", t))
+	}
+	return nil, false
+}
+
+// parseFloat parses a float from a string using the separators of the options
+func parseFloat(v string, opts CastOptions) (float64, error) {
+	v = strings.TrimSpace(removeSeparator(v, opts.ThousandsSeparator))
+	if opts.DecimalSeparator != '.' {
+		if strings.ContainsRune(v, '.') {
+			return 0, fmt.Errorf("unexpected decimal separator in %v", v)
+		}
+		v = strings.Replace(v, string(opts.DecimalSeparator), ".", 1)
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+// removeSeparator removes every occurrence of separator from v, unless separator is zero
+func removeSeparator(v string, separator rune) string {
+	if separator == 0 {
+		return v
+	}
+	return strings.ReplaceAll(v, string(separator), "")
+}
@@ -0,0 +1,95 @@
+package series
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSeries_AsType(t *testing.T) {
+	s := New([]float64{1, 2.5, 3, 0}, Float, "Floats")
+	s.Elem(3).Set(nil)
+
+	expected := "{Floats [1 NaN 3 NaN] int}"
+	se, err := s.AsType(Int)
+
+	var castErr *CastError
+	if !errors.As(err, &castErr) {
+		t.Fatalf("Expected a CastError, got %v", err)
+	}
+	if len(castErr.Rows) != 1 || castErr.Rows[0] != 1 || castErr.Values[0] != 2.5 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", []int{1}, castErr.Rows)
+	}
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	se, err = s.AsType(Int, CastOptions{Errors: "coerce"})
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected AsType to panic, but it did not")
+		}
+	}()
+
+	_, _ = s.AsType(Int, CastOptions{Errors: "ignore"})
+}
+
+func TestSeries_AsTypeStrings(t *testing.T) {
+	s := New([]string{"1.234,5", " 7 ", "abc"}, String, "Strings")
+
+	expected := "{Strings [1234.5 7 NaN] float}"
+	se, err := s.AsType(Float, CastOptions{DecimalSeparator: ',', ThousandsSeparator: '.', Errors: "coerce"})
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	expected = "{Strings [1234,5 7] string}"
+	se, _ = se.Head(2).AsType(String, CastOptions{DecimalSeparator: ','})
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	expected = "{Strings [true false NaN] bool}"
+	se, err = New([]string{"true", "0", "maybe"}, String, "Strings").AsType(Boolean)
+	if err == nil {
+		t.Errorf("Expected an error, got nil")
+	}
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+}
+
+func TestSeries_AsTypeOther(t *testing.T) {
+	tests := []struct {
+		s        Series
+		t        Type
+		expected string
+	}{
+		{New([]int{0, 1}, Int, "Integers"), Boolean, "{Integers [false true] bool}"},
+		{New([]bool{true, false}, Boolean, "Booleans"), Int, "{Booleans [1 0] int}"},
+		{New([]int{3, 1, 3}, Int, "Integers"), Categorical, "{Integers [3 1 3] category}"},
+		{New([]string{"a", "b"}, String, "Strings"), Runic, "{Strings [a b] rune}"},
+		{New([]string{"2024-01-02T00:00:00Z"}, String, "Times"), Datetime, "{Times [2024-01-02 00:00:00 +0000 UTC] datetime}"},
+		{New([]time.Time{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}, Datetime, "Times"), String, "{Times [2024-01-02T00:00:00Z] string}"},
+	}
+
+	for _, test := range tests {
+		se, err := test.s.AsType(test.t)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if se.String() != test.expected {
+			t.Errorf("Expected:\n%v\nGot:\n%v", test.expected, se.String())
+		}
+	}
+}
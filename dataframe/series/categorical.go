@@ -0,0 +1,191 @@
+package series
+
+import (
+	"fmt"
+	"sort"
+)
+
+// categoryTable is the fixed set of categories shared by the elements of a categorical series
+type categoryTable struct {
+	values []string
+	codes  map[string]int
+}
+
+// newCategoryTable creates a table of the categories, which must be unique
+func newCategoryTable(categories []string) *categoryTable {
+	table := &categoryTable{
+		values: append([]string{}, categories...),
+		codes:  make(map[string]int, len(categories)),
+	}
+	for i, c := range categories {
+		if _, ok := table.codes[c]; ok {
+			panic(fmt.Errorf("duplicate category %v", c))
+		}
+		table.codes[c] = i
+	}
+	return table
+}
+
+type categoricalElement struct {
+	code  int
+	table *categoryTable
+	nan   bool
+}
+
+// force implementation of Element interface
+var _ Element = (*categoricalElement)(nil)
+
+func (c *categoricalElement) Set(value any) {
+	c.nan = false
+
+	var v string
+	switch v_ := value.(type) {
+	case string:
+		v = v_
+	case rune:
+		v = string(v_)
+	default:
+		c.nan = true
+		return
+	}
+
+	code, ok := c.table.lookup(v)
+	if !ok {
+		c.nan = true
+		return
+	}
+	c.code = code
+}
+
+// lookup returns the code of a category, or false if it is not one of the categories
+func (t *categoryTable) lookup(v string) (int, bool) {
+	if t == nil {
+		return 0, false
+	}
+	code, ok := t.codes[v]
+	return code, ok
+}
+
+func (c categoricalElement) Get() any {
+	if c.nan {
+		return ""
+	}
+	return c.table.values[c.code]
+}
+
+func (c categoricalElement) IsNA() bool {
+	return c.nan
+}
+
+func (c categoricalElement) Type() Type {
+	return Categorical
+}
+
+func (c categoricalElement) IsNumeric() bool {
+	return false
+}
+
+// categoricalElements is the implementation of the Element interface for categorical types, where every element
+// refers to the same table of categories
+type categoricalElements struct {
+	table    *categoryTable
+	elements []categoricalElement
+}
+
+func (c categoricalElements) Len() int           { return len(c.elements) }
+func (c categoricalElements) Elem(j int) Element { return &c.elements[j] }
+func (c categoricalElements) Values() []any {
+	v := make([]any, len(c.elements))
+	for j, e := range c.elements {
+		v[j] = e.Get()
+	}
+	return v
+}
+
+// newCategoricalElements allocates n NA elements referring to table
+func newCategoricalElements(n int, table *categoryTable) categoricalElements {
+	elements := make([]categoricalElement, n)
+	for i := range elements {
+		elements[i] = categoricalElement{table: table, nan: true}
+	}
+	return categoricalElements{table: table, elements: elements}
+}
+
+// NewCategorical creates a new categorical series from a slice of values and a fixed set of categories. Values which
+// are not one of the categories are NA. If categories is nil, the sorted unique values are used as the categories
+func NewCategorical(values []string, categories []string, name string) Series {
+	if categories == nil {
+		seen := make(map[string]struct{})
+		categories = []string{}
+		for _, v := range values {
+			if _, ok := seen[v]; !ok {
+				seen[v] = struct{}{}
+				categories = append(categories, v)
+			}
+		}
+		sort.Strings(categories)
+	}
+
+	elements := newCategoricalElements(len(values), newCategoryTable(categories))
+	for i, v := range values {
+		elements.elements[i].Set(v)
+	}
+	return Series{Name: name, elements: elements, t: Categorical}
+}
+
+// ToCategorical converts a series into a categorical series of the string form of its values. NA values are kept as
+// NA. If no categories are given, the sorted unique values are used as the categories
+func (s Series) ToCategorical(categories ...string) Series {
+	if s.t == Categorical && categories == nil {
+		return s.Copy()
+	}
+
+	values := make([]string, s.Len())
+	for i := range values {
+		values[i] = fmt.Sprint(s.Val(i))
+	}
+
+	if categories == nil {
+		var present []string
+		for i, v := range values {
+			if !s.Elem(i).IsNA() {
+				present = append(present, v)
+			}
+		}
+		categories = NewCategorical(present, nil, s.Name).Categories()
+	}
+
+	se := NewCategorical(values, categories, s.Name)
+	for i := 0; i < s.Len(); i++ {
+		if s.Elem(i).IsNA() {
+			se.Elem(i).Set(nil)
+		}
+	}
+	return se
+}
+
+// Categories returns the categories of a categorical series in order of their codes
+func (s Series) Categories() []string {
+	if s.t != Categorical {
+		panic(fmt.Errorf("categories are only supported for type %v, but got %v", Categorical, s.t))
+	}
+	return append([]string{}, s.elements.(categoricalElements).table.values...)
+}
+
+// Codes returns an int series of the position of the value of each element in the categories, with NA values
+// preserved and given the code -1
+func (s Series) Codes() Series {
+	if s.t != Categorical {
+		panic(fmt.Errorf("codes are only supported for type %v, but got %v", Categorical, s.t))
+	}
+
+	elements := s.elements.(categoricalElements).elements
+	values := make(intElements, len(elements))
+	for i, e := range elements {
+		values[i] = intElement{e: e.code, nan: e.nan}
+		if e.nan {
+			values[i].e = -1
+		}
+	}
+	return Series{Name: s.Name, elements: values, t: Int}
+}
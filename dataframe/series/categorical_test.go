@@ -0,0 +1,127 @@
+package series
+
+import (
+	"testing"
+)
+
+func TestNewSeriesCategorical(t *testing.T) {
+	expected := "{Colours [red blue red green] category}"
+	s := New([]string{"red", "blue", "red", "green"}, Categorical, "Colours")
+
+	if s.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.String())
+	}
+
+	categories := s.Categories()
+	if len(categories) != 3 || categories[0] != "blue" || categories[1] != "green" || categories[2] != "red" {
+		t.Errorf("Expected:\n%v\nGot:\n%v", []string{"blue", "green", "red"}, categories)
+	}
+
+	expected = "{Colours [2 0 2 1] int}"
+	if s.Codes().String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.Codes().String())
+	}
+}
+
+func TestNewCategorical(t *testing.T) {
+	expected := "{Sizes [small NaN large] category}"
+	s := NewCategorical([]string{"small", "huge", "large"}, []string{"small", "medium", "large"}, "Sizes")
+
+	if s.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.String())
+	}
+
+	expected = "{Sizes [0 NaN 2] int}"
+	if s.Codes().String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.Codes().String())
+	}
+	if s.Codes().Val(1) != -1 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", -1, s.Codes().Val(1))
+	}
+
+	// Setting a value outside of the categories gives NA
+	s.Elem(0).Set("huge")
+	if !s.Elem(0).IsNA() {
+		t.Errorf("Expected element to be NA")
+	}
+
+	s.Elem(0).Set("medium")
+	if s.Val(0) != "medium" {
+		t.Errorf("Expected:\n%v\nGot:\n%v", "medium", s.Val(0))
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected NewCategorical to panic, but it did not")
+		}
+	}()
+
+	NewCategorical([]string{"a"}, []string{"a", "a"}, "Duplicates")
+}
+
+func TestSeries_ToCategorical(t *testing.T) {
+	expected := "{Integers [3 1 NaN 3] category}"
+	s := New([]float64{3, 1, 0, 3}, Float, "Integers")
+	s.Elem(2).Set(nil)
+	se := s.ToCategorical()
+
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+	if len(se.Categories()) != 2 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 2, len(se.Categories()))
+	}
+
+	se = New([]string{"b", "a"}, String, "Strings").ToCategorical("b", "a", "c")
+	expected = "{Strings [0 1] int}"
+
+	if se.Codes().String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.Codes().String())
+	}
+}
+
+func TestSeries_CategoricalOperations(t *testing.T) {
+	s := NewCategorical([]string{"high", "low", "medium", "low"}, []string{"low", "medium", "high"}, "Levels")
+
+	// Categories are ordered by their codes rather than their values
+	expected := "{Levels [low low medium high] category}"
+	if se := s.SortValues(); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	expected = "{Levels [medium low] category}"
+	if se := s.Slice(2, 4); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	expected = "{Levels [high NaN] category}"
+	if se := s.Take(0, -1); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	se := s.Copy()
+	se.Append("medium")
+	se.Elem(0).Set("low")
+	expected = "{Levels [low low medium low medium] category}"
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+	if s.Val(0) != "high" {
+		t.Errorf("Expected copy to leave the original unchanged, got %v", s.Val(0))
+	}
+
+	if s.Max() != "high" {
+		t.Errorf("Expected:\n%v\nGot:\n%v", "high", s.Max())
+	}
+
+	if !s.IsObject() || s.IsNumeric() {
+		t.Errorf("Expected categorical series to be an object series")
+	}
+
+	empty := NewEmptySeriesLike(s, 2, "Empty")
+	empty.Elem(1).Set("medium")
+	expected = "{Empty [NaN medium] category}"
+	if empty.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, empty.String())
+	}
+}
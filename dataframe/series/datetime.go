@@ -0,0 +1,223 @@
+package series
+
+import (
+	"fmt"
+	"time"
+)
+
+type datetimeElement struct {
+	e   time.Time
+	nan bool
+}
+
+// force implementation of Element interface
+var _ Element = (*datetimeElement)(nil)
+
+func (d *datetimeElement) Set(value any) {
+	d.nan = false
+
+	switch v := value.(type) {
+	case time.Time:
+		d.e = v
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			d.nan = true
+			return
+		}
+		d.e = t
+	default:
+		d.nan = true
+		return
+	}
+}
+
+func (d datetimeElement) Get() any {
+	return d.e
+}
+
+func (d datetimeElement) IsNA() bool {
+	return d.nan
+}
+
+func (d datetimeElement) Type() Type {
+	return Datetime
+}
+
+func (d datetimeElement) IsNumeric() bool {
+	return false
+}
+
+// datetimeElements is the implementation of the Element interface for datetime types
+type datetimeElements []datetimeElement
+
+func (d datetimeElements) Len() int           { return len(d) }
+func (d datetimeElements) Elem(j int) Element { return &d[j] }
+func (d datetimeElements) Values() []any {
+	v := make([]any, len(d))
+	for j, e := range d {
+		v[j] = e.e
+	}
+	return v
+}
+
+// ToDatetime parses a string series into a datetime series using layout, in the format accepted by time.Parse.
+// Times without a zone are interpreted in location, which defaults to UTC. Values which cannot be parsed are NA
+func (s Series) ToDatetime(layout string, location ...*time.Location) Series {
+	if s.t != String {
+		panic(fmt.Errorf("cannot parse datetimes from a series of type %v", s.t))
+	}
+	if len(location) > 1 {
+		panic(fmt.Errorf("only one location allowed"))
+	}
+	if len(location) == 0 {
+		location = []*time.Location{time.UTC}
+	}
+
+	values := make(datetimeElements, s.Len())
+	for i := range values {
+		if s.Elem(i).IsNA() {
+			values[i].nan = true
+			continue
+		}
+
+		t, err := time.ParseInLocation(layout, s.Val(i).(string), location[0])
+		if err != nil {
+			values[i].nan = true
+			continue
+		}
+		values[i].e = t
+	}
+
+	return Series{Name: s.Name, elements: values, t: Datetime}
+}
+
+// DatetimeMethods provides access to the components of the values of a datetime series
+type DatetimeMethods struct {
+	s Series
+}
+
+// Dt returns the datetime methods of a datetime series
+func (s Series) Dt() DatetimeMethods {
+	if s.t != Datetime {
+		panic(fmt.Errorf("datetime methods are only supported for type %v, but got %v", Datetime, s.t))
+	}
+	return DatetimeMethods{s: s}
+}
+
+// component returns an int series of a component of each time, with NA values preserved
+func (d DatetimeMethods) component(f func(t time.Time) int) Series {
+	values := make(intElements, d.s.Len())
+	for i := range values {
+		if d.s.Elem(i).IsNA() {
+			values[i].nan = true
+			continue
+		}
+		values[i].e = f(d.s.Val(i).(time.Time))
+	}
+	return Series{Name: d.s.Name, elements: values, t: Int}
+}
+
+// Year returns the year of each time
+func (d DatetimeMethods) Year() Series {
+	return d.component(func(t time.Time) int { return t.Year() })
+}
+
+// Month returns the month of each time, from 1 for January to 12 for December
+func (d DatetimeMethods) Month() Series {
+	return d.component(func(t time.Time) int { return int(t.Month()) })
+}
+
+// Day returns the day of the month of each time
+func (d DatetimeMethods) Day() Series {
+	return d.component(func(t time.Time) int { return t.Day() })
+}
+
+// DayOfYear returns the day of the year of each time, from 1 to 366
+func (d DatetimeMethods) DayOfYear() Series {
+	return d.component(func(t time.Time) int { return t.YearDay() })
+}
+
+// Weekday returns the day of the week of each time, from 0 for Sunday to 6 for Saturday
+func (d DatetimeMethods) Weekday() Series {
+	return d.component(func(t time.Time) int { return int(t.Weekday()) })
+}
+
+// Hour returns the hour of each time
+func (d DatetimeMethods) Hour() Series {
+	return d.component(func(t time.Time) int { return t.Hour() })
+}
+
+// Minute returns the minute of each time
+func (d DatetimeMethods) Minute() Series {
+	return d.component(func(t time.Time) int { return t.Minute() })
+}
+
+// Second returns the second of each time
+func (d DatetimeMethods) Second() Series {
+	return d.component(func(t time.Time) int { return t.Second() })
+}
+
+// convert returns a datetime series with f applied to each time, with NA values preserved
+func (d DatetimeMethods) convert(f func(t time.Time) time.Time) Series {
+	values := make(datetimeElements, d.s.Len())
+	for i := range values {
+		if d.s.Elem(i).IsNA() {
+			values[i].nan = true
+			continue
+		}
+		values[i].e = f(d.s.Val(i).(time.Time))
+	}
+	return Series{Name: d.s.Name, elements: values, t: Datetime}
+}
+
+// In returns the times converted to location
+func (d DatetimeMethods) In(location *time.Location) Series {
+	return d.convert(func(t time.Time) time.Time { return t.In(location) })
+}
+
+// Floor returns the start of the period of frequency freq containing each time. The frequency is one of the calendar
+// periods "Y", "Q", "M", "W" (weeks starting on Monday) or "D", or a duration accepted by time.ParseDuration such as
+// "15m" or "1h". Periods are aligned to the wall clock of the location of each time
+func (d DatetimeMethods) Floor(freq string) Series {
+	return d.convert(floorFunc(freq))
+}
+
+// floorFunc returns a function which rounds a time down to the start of its period of frequency freq
+func floorFunc(freq string) func(t time.Time) time.Time {
+	midnight := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+
+	switch freq {
+	case "Y":
+		return func(t time.Time) time.Time { return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location()) }
+	case "Q":
+		return func(t time.Time) time.Time {
+			month := time.Month((int(t.Month())-1)/3*3 + 1)
+			return time.Date(t.Year(), month, 1, 0, 0, 0, 0, t.Location())
+		}
+	case "M":
+		return func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()) }
+	case "W":
+		return func(t time.Time) time.Time {
+			days := (int(t.Weekday()) + 6) % 7
+			return midnight(t).AddDate(0, 0, -days)
+		}
+	case "D":
+		return midnight
+	}
+
+	duration, err := time.ParseDuration(freq)
+	if err != nil || duration <= 0 {
+		panic(fmt.Errorf("invalid frequency %v", freq))
+	}
+
+	return func(t time.Time) time.Time {
+		if duration > 24*time.Hour {
+			return t.Truncate(duration)
+		}
+		start := midnight(t)
+		return start.Add(t.Sub(start).Truncate(duration))
+	}
+}
@@ -0,0 +1,122 @@
+package series
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewSeriesDatetime(t *testing.T) {
+	expected := "{Times [2024-01-02 03:04:05 +0000 UTC NaN] datetime}"
+	s := New([]time.Time{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), {}}, Datetime, "Times")
+	s.Elem(1).Set(nil)
+
+	if s.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.String())
+	}
+}
+
+func TestSeries_ToDatetime(t *testing.T) {
+	expected := "{Times [2024-03-10 14:30:00 +0000 UTC NaN NaN] datetime}"
+	s := New([]string{"2024-03-10 14:30", "not a time", ""}, String, "Times")
+	s.Elem(2).Set(nil)
+	se := s.ToDatetime("2006-01-02 15:04")
+
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	location := time.FixedZone("UTC+10", 10*60*60)
+	se = s.ToDatetime("2006-01-02 15:04", location)
+
+	if se.Val(0).(time.Time).UTC().Hour() != 4 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 4, se.Val(0).(time.Time).UTC().Hour())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected ToDatetime to panic, but it did not")
+		}
+	}()
+
+	New([]int{1, 2}, Int, "Integers").ToDatetime(time.RFC3339)
+}
+
+func TestSeries_Dt(t *testing.T) {
+	s := New([]time.Time{
+		time.Date(2024, 2, 29, 13, 45, 30, 0, time.UTC),
+		time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+		{},
+	}, Datetime, "Times")
+	s.Elem(2).Set(nil)
+
+	tests := []struct {
+		name     string
+		got      Series
+		expected string
+	}{
+		{"Year", s.Dt().Year(), "{Times [2024 2023 NaN] int}"},
+		{"Month", s.Dt().Month(), "{Times [2 12 NaN] int}"},
+		{"Day", s.Dt().Day(), "{Times [29 31 NaN] int}"},
+		{"DayOfYear", s.Dt().DayOfYear(), "{Times [60 365 NaN] int}"},
+		{"Weekday", s.Dt().Weekday(), "{Times [4 0 NaN] int}"},
+		{"Hour", s.Dt().Hour(), "{Times [13 0 NaN] int}"},
+		{"Minute", s.Dt().Minute(), "{Times [45 0 NaN] int}"},
+		{"Second", s.Dt().Second(), "{Times [30 0 NaN] int}"},
+	}
+
+	for _, test := range tests {
+		if test.got.String() != test.expected {
+			t.Errorf("%v Expected:\n%v\nGot:\n%v", test.name, test.expected, test.got.String())
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Dt to panic, but it did not")
+		}
+	}()
+
+	New([]int{1, 2}, Int, "Integers").Dt()
+}
+
+func TestSeries_DtFloor(t *testing.T) {
+	s := New([]time.Time{time.Date(2024, 8, 15, 13, 47, 30, 0, time.UTC)}, Datetime, "Times")
+
+	expected := map[string]time.Time{
+		"Y":   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"Q":   time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		"M":   time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC),
+		"W":   time.Date(2024, 8, 12, 0, 0, 0, 0, time.UTC),
+		"D":   time.Date(2024, 8, 15, 0, 0, 0, 0, time.UTC),
+		"1h":  time.Date(2024, 8, 15, 13, 0, 0, 0, time.UTC),
+		"15m": time.Date(2024, 8, 15, 13, 45, 0, 0, time.UTC),
+	}
+
+	for freq, e := range expected {
+		floor := s.Dt().Floor(freq).Val(0).(time.Time)
+		if !floor.Equal(e) {
+			t.Errorf("%v Expected:\n%v\nGot:\n%v", freq, e, floor)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Floor to panic, but it did not")
+		}
+	}()
+
+	s.Dt().Floor("not a frequency")
+}
+
+func TestSeries_SortDatetime(t *testing.T) {
+	expected := "{Times [2023-01-01 00:00:00 +0000 UTC 2024-01-01 00:00:00 +0000 UTC] datetime}"
+	s := New([]time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, Datetime, "Times")
+	s.Sort()
+
+	if s.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.String())
+	}
+}
@@ -0,0 +1,119 @@
+package series
+
+import "fmt"
+
+// Value is the set of Go types which can be held by a typed series
+type Value interface {
+	int | float64 | bool | string
+}
+
+// Of is a series with values of the Go type T, which gives compile-time type safety when working with the values of
+// a series. It can be converted to and from the untyped Series
+type Of[T Value] struct {
+	Name   string
+	values []T
+	na     []bool
+}
+
+// typeOf returns the series type which holds values of the Go type T
+func typeOf[T Value]() Type {
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		return Int
+	case float64:
+		return Float
+	case bool:
+		return Boolean
+	default:
+		return String
+	}
+}
+
+// NewOf creates a new typed series from a slice of values and a name
+func NewOf[T Value](values []T, name string) Of[T] {
+	return Of[T]{
+		Name:   name,
+		values: append([]T{}, values...),
+		na:     make([]bool, len(values)),
+	}
+}
+
+// From creates a typed series from a series, which must hold values of the Go type T. NA values are preserved
+func From[T Value](s Series) Of[T] {
+	if t := typeOf[T](); s.t != t {
+		panic(fmt.Errorf("series of type %v cannot be used as type %v", s.t, t))
+	}
+
+	o := Of[T]{Name: s.Name, values: make([]T, s.Len()), na: make([]bool, s.Len())}
+	for i := 0; i < s.Len(); i++ {
+		o.values[i] = s.Val(i).(T)
+		o.na[i] = s.Elem(i).IsNA()
+	}
+	return o
+}
+
+// Series returns the typed series as an untyped series, preserving NA values
+func (o Of[T]) Series() Series {
+	s := New(o.values, typeOf[T](), o.Name)
+	for i, na := range o.na {
+		if na {
+			s.Elem(i).Set(nil)
+		}
+	}
+	return s
+}
+
+// Len returns the number of values in the typed series
+func (o Of[T]) Len() int {
+	return len(o.values)
+}
+
+// At returns the value at index i, and false if the value is NA
+func (o Of[T]) At(i int) (T, bool) {
+	return o.values[i], !o.na[i]
+}
+
+// Values returns a copy of the values of the typed series. NA values are included as their underlying value
+func (o Of[T]) Values() []T {
+	return append([]T{}, o.values...)
+}
+
+// Map returns a new typed series with f applied to each non-NA value, NA values are preserved
+func (o Of[T]) Map(f func(T) T) Of[T] {
+	return MapTo(o, f)
+}
+
+// Filter returns a new typed series containing the non-NA values for which f returns true
+func (o Of[T]) Filter(f func(T) bool) Of[T] {
+	filtered := Of[T]{Name: o.Name, values: []T{}, na: []bool{}}
+	for i, v := range o.values {
+		if !o.na[i] && f(v) {
+			filtered.values = append(filtered.values, v)
+			filtered.na = append(filtered.na, false)
+		}
+	}
+	return filtered
+}
+
+// MapTo returns a new typed series of values of type U with f applied to each non-NA value, NA values are preserved
+func MapTo[T, U Value](o Of[T], f func(T) U) Of[U] {
+	mapped := Of[U]{Name: o.Name, values: make([]U, len(o.values)), na: append([]bool{}, o.na...)}
+	for i, v := range o.values {
+		if !o.na[i] {
+			mapped.values[i] = f(v)
+		}
+	}
+	return mapped
+}
+
+// Reduce combines the non-NA values of the typed series in order into a single value, starting from initial
+func Reduce[T Value, A any](o Of[T], initial A, f func(A, T) A) A {
+	result := initial
+	for i, v := range o.values {
+		if !o.na[i] {
+			result = f(result, v)
+		}
+	}
+	return result
+}
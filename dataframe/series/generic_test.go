@@ -0,0 +1,90 @@
+package series
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNewOf(t *testing.T) {
+	expected := "{Integers [1 2 3] int}"
+	o := NewOf([]int{1, 2, 3}, "Integers")
+
+	if o.Series().String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, o.Series().String())
+	}
+
+	if o.Len() != 3 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 3, o.Len())
+	}
+}
+
+func TestFrom(t *testing.T) {
+	s := New([]float64{1.5, math.NaN(), 3.5}, Float, "Floats")
+	o := From[float64](s)
+
+	if v, ok := o.At(0); !ok || v != 1.5 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 1.5, v)
+	}
+	if _, ok := o.At(1); ok {
+		t.Errorf("Expected value to be NA")
+	}
+
+	expected := "{Floats [1.5 NaN 3.5] float}"
+	if o.Series().String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, o.Series().String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected From to panic, but it did not")
+		}
+	}()
+
+	From[int](s)
+}
+
+func TestOf_Map(t *testing.T) {
+	s := New([]float64{1.5, math.NaN(), 3.5}, Float, "Floats")
+
+	expected := "{Floats [3 NaN 7] float}"
+	doubled := From[float64](s).Map(func(v float64) float64 { return v * 2 })
+	if doubled.Series().String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, doubled.Series().String())
+	}
+
+	expected = "{Floats [1 NaN 3] int}"
+	truncated := MapTo(From[float64](s), func(v float64) int { return int(v) })
+	if truncated.Series().String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, truncated.Series().String())
+	}
+}
+
+func TestOf_Filter(t *testing.T) {
+	expected := "{Strings [apple avocado] string}"
+	o := NewOf([]string{"apple", "banana", "avocado"}, "Strings")
+	filtered := o.Filter(func(v string) bool { return strings.HasPrefix(v, "a") })
+
+	if filtered.Series().String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, filtered.Series().String())
+	}
+
+	if values := o.Values(); len(values) != 3 {
+		t.Errorf("Expected filter to leave the original unchanged, got %v", values)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	s := New([]int{1, 2, 3, 4}, Int, "Integers")
+	s.Elem(3).Set(nil)
+
+	sum := Reduce(From[int](s), 0, func(total, v int) int { return total + v })
+	if sum != 6 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 6, sum)
+	}
+
+	joined := Reduce(From[int](s), "", func(total string, v int) string { return total + string(rune('0'+v)) })
+	if joined != "123" {
+		t.Errorf("Expected:\n%v\nGot:\n%v", "123", joined)
+	}
+}
@@ -1,6 +1,9 @@
 package series
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // NewRangedSeries creates a new Series defined for a range of integers.
 func NewRangedSeries(start, end int, t Type, name string) Series {
@@ -23,9 +26,22 @@ func NewEmptySeries(t Type, size int, name string) Series {
 		return New(make([]bool, size), t, name)
 	case String:
 		return New(make([]string, size), t, name)
+	case Datetime:
+		return New(make([]time.Time, size), t, name)
+	case Categorical:
+		return NewCategorical(make([]string, size), []string{}, name)
 	case Runic:
-		panic("not implemented")
+		return New(make([]rune, size), t, name)
 	default:
 		panic(fmt.Errorf("type %v not supported", t))
 	}
 }
+
+// NewEmptySeriesLike creates a new Series with no values of the same type as s. A categorical Series shares the
+// categories of s, and its values are NA until they are set.
+func NewEmptySeriesLike(s Series, size int, name string) Series {
+	if s.t == Categorical {
+		return Series{Name: name, elements: newCategoricalElements(size, s.elements.(categoricalElements).table), t: Categorical}
+	}
+	return NewEmptySeries(s.t, size, name)
+}
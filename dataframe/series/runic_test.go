@@ -0,0 +1,85 @@
+package series
+
+import (
+	"testing"
+)
+
+func TestNewSeriesRunic(t *testing.T) {
+	expected := "{Runes [a b ç] rune}"
+	s := New([]rune{'a', 'b', 'ç'}, Runic, "Runes")
+
+	if s.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.String())
+	}
+	// Output: {Runes [a b ç] rune}
+
+	if s.Val(2) != 'ç' {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 'ç', s.Val(2))
+	}
+
+	if s.IsNumeric() || !s.IsObject() {
+		t.Errorf("Expected rune series to be an object series")
+	}
+}
+
+func TestSeries_RunicSet(t *testing.T) {
+	expected := "{Runes [x y NaN NaN] rune}"
+	s := NewEmptySeries(Runic, 4, "Runes")
+	s.Elem(0).Set("x")
+	s.Elem(1).Set(int('y'))
+	s.Elem(2).Set("too long")
+	s.Elem(3).Set(1.5)
+
+	if s.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.String())
+	}
+}
+
+func TestSeries_RunicCopyAppend(t *testing.T) {
+	expected := "{Runes [a b c] rune}"
+	s := New([]rune{'a', 'b'}, Runic, "Runes")
+	se := s.Copy()
+	se.Append('c')
+
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	expected = "{Runes [a b] rune}"
+	if s.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.String())
+	}
+}
+
+func TestSeries_RunicSliceTake(t *testing.T) {
+	s := New([]rune{'a', 'b', 'c', 'd'}, Runic, "Runes")
+
+	expected := "{Runes [b c] rune}"
+	if se := s.Slice(1, 3); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	expected = "{Runes [d NaN a] rune}"
+	if se := s.Take(3, -1, 0); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+}
+
+func TestSeries_RunicSort(t *testing.T) {
+	expected := "{Runes [a b c] rune}"
+	s := New([]rune{'c', 'a', 'b'}, Runic, "Runes")
+	s.Sort()
+
+	if s.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.String())
+	}
+
+	expected = "{Runes [c b a] rune}"
+	if se := s.SortValues(SortSettings{Descending: true}); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	if s.Max() != 'c' {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 'c', s.Max())
+	}
+}
@@ -2,6 +2,8 @@ package series
 
 import (
 	"fmt"
+	"math"
+	"time"
 )
 
 // Series is a collection of elements of the same type and
@@ -81,6 +83,19 @@ func (s stringElements) Values() []any {
 	return v
 }
 
+// runicElements is the implementation of the Element interface for rune types
+type runicElements []runicElement
+
+func (r runicElements) Len() int           { return len(r) }
+func (r runicElements) Elem(j int) Element { return &r[j] }
+func (r runicElements) Values() []any {
+	v := make([]any, len(r))
+	for j, e := range r {
+		v[j] = e.e
+	}
+	return v
+}
+
 // Type defines the type of the series
 type Type string
 
@@ -90,10 +105,22 @@ const (
 	Boolean Type = "bool"
 	String  Type = "string"
 	Runic   Type = "rune"
+
+	Datetime    Type = "datetime"
+	Categorical Type = "category"
 )
 
-// New creates a new series from a slice of values of type t, and a name
+// New creates a new series from a slice of values of type t, and a name. A categorical series created from a slice
+// of strings uses the sorted unique values as its categories
 func New(v any, t Type, name string) Series {
+	if t == Categorical {
+		v_, ok := v.([]string)
+		if !ok {
+			panic(fmt.Errorf("categorical series must be created from strings, but got %T", v))
+		}
+		return NewCategorical(v_, nil, name)
+	}
+
 	s := Series{Name: name, t: t}
 
 	allocMemory := func(n int) {
@@ -106,8 +133,10 @@ func New(v any, t Type, name string) Series {
 			s.elements = make(booleanElements, n)
 		case String:
 			s.elements = make(stringElements, n)
+		case Datetime:
+			s.elements = make(datetimeElements, n)
 		case Runic:
-			panic("not implemented")
+			s.elements = make(runicElements, n)
 		}
 	}
 
@@ -142,8 +171,18 @@ func New(v any, t Type, name string) Series {
 		for i, e := range v_ {
 			s.elements.Elem(i).Set(e)
 		}
+	case []time.Time:
+		l := len(v_)
+		allocMemory(l)
+		for i, e := range v_ {
+			s.elements.Elem(i).Set(e)
+		}
 	case []rune:
-		panic("not implemented")
+		l := len(v_)
+		allocMemory(l)
+		for i, e := range v_ {
+			s.elements.Elem(i).Set(e)
+		}
 	default:
 		panic("unsupported type")
 	}
@@ -170,8 +209,15 @@ func (s Series) Copy() Series {
 	case String:
 		elements = make(stringElements, s.elements.Len())
 		copy(elements.(stringElements), s.elements.(stringElements))
+	case Datetime:
+		elements = make(datetimeElements, s.elements.Len())
+		copy(elements.(datetimeElements), s.elements.(datetimeElements))
+	case Categorical:
+		e := s.elements.(categoricalElements)
+		elements = categoricalElements{table: e.table, elements: append([]categoricalElement{}, e.elements...)}
 	case Runic:
-		panic("not implemented")
+		elements = make(runicElements, s.elements.Len())
+		copy(elements.(runicElements), s.elements.(runicElements))
 	}
 
 	return Series{
@@ -197,14 +243,29 @@ func (s *Series) Append(v any) {
 		s.elements = append(s.elements.(booleanElements), booleanElement{e: v.(bool)})
 	case String:
 		s.elements = append(s.elements.(stringElements), stringElement{e: v.(string)})
+	case Datetime:
+		s.elements = append(s.elements.(datetimeElements), datetimeElement{e: v.(time.Time)})
+	case Categorical:
+		e := s.elements.(categoricalElements)
+		e.elements = append(e.elements, categoricalElement{table: e.table})
+		e.elements[len(e.elements)-1].Set(v)
+		s.elements = e
 	case Runic:
-		panic("not implemented")
+		s.elements = append(s.elements.(runicElements), runicElement{e: v.(rune)})
 	}
 }
 
 // String returns the Stringer implementation of the series
 func (s Series) String() string {
-	return fmt.Sprintf("{%v %v %v}", s.Name, s.elements.Values(), s.t)
+	values := s.elements.Values()
+	for i := range values {
+		if s.Elem(i).IsNA() {
+			values[i] = "NaN"
+		} else if r, ok := values[i].(rune); ok {
+			values[i] = string(r)
+		}
+	}
+	return fmt.Sprintf("{%v %v %v}", s.Name, values, s.t)
 }
 
 // Val returns the value of the element at index i
@@ -227,6 +288,17 @@ func (s Series) HasNa() bool {
 	return false
 }
 
+// DropNA returns a new series with the NA elements removed
+func (s Series) DropNA() Series {
+	var positions []int
+	for i := 0; i < s.Len(); i++ {
+		if !s.Elem(i).IsNA() {
+			positions = append(positions, i)
+		}
+	}
+	return s.Take(positions...)
+}
+
 // Slice returns a copy of the series from index a to index b
 func (s Series) Slice(a, b int) Series {
 	if a < 0 {
@@ -250,8 +322,12 @@ func (s Series) Slice(a, b int) Series {
 			se.elements = make(booleanElements, n)
 		case String:
 			se.elements = make(stringElements, n)
+		case Datetime:
+			se.elements = make(datetimeElements, n)
+		case Categorical:
+			se.elements = newCategoricalElements(n, s.elements.(categoricalElements).table)
 		case Runic:
-			panic("not implemented")
+			se.elements = make(runicElements, n)
 		default:
 			panic("unsupported type")
 		}
@@ -259,11 +335,92 @@ func (s Series) Slice(a, b int) Series {
 	allocMemory(n)
 
 	for i := a; i < b; i++ {
+		if s.Elem(i).IsNA() {
+			se.Elem(i - a).Set(nil)
+			continue
+		}
 		se.Elem(i - a).Set(s.Val(i))
 	}
 	return se
 }
 
+// Take returns a new series containing the elements at the specified positions, in the order given. A position of -1
+// produces an NA element
+func (s Series) Take(positions ...int) Series {
+	for _, pos := range positions {
+		if pos < -1 || pos >= s.Len() {
+			panic(fmt.Errorf("position %v out of range", pos))
+		}
+	}
+
+	se := Series{Name: s.Name, t: s.t}
+	switch e := s.elements.(type) {
+	case intElements:
+		se.elements = take(e, positions)
+	case floatElements:
+		se.elements = take(e, positions)
+	case booleanElements:
+		se.elements = take(e, positions)
+	case stringElements:
+		se.elements = take(e, positions)
+	case datetimeElements:
+		se.elements = take(e, positions)
+	case runicElements:
+		se.elements = take(e, positions)
+	case categoricalElements:
+		taken := categoricalElements{table: e.table, elements: take(e.elements, positions)}
+		for i := range taken.elements {
+			taken.elements[i].table = e.table
+		}
+		se.elements = taken
+	default:
+		panic(fmt.Errorf("type %v not supported", s.t))
+	}
+
+	for i, pos := range positions {
+		if pos == -1 {
+			se.Elem(i).Set(nil)
+		}
+	}
+	return se
+}
+
+// take copies the elements at the specified positions into a new collection, preserving NA values
+func take[S ~[]E, E any](elements S, positions []int) S {
+	taken := make(S, len(positions))
+	for i, pos := range positions {
+		if pos >= 0 {
+			taken[i] = elements[pos]
+		}
+	}
+	return taken
+}
+
+// Filter returns a new series containing the elements where the boolean mask is true, NA values in the mask are
+// treated as false
+func (s Series) Filter(mask Series) Series {
+	if mask.Len() != s.Len() {
+		panic(fmt.Errorf("mask length %v does not match series length %v", mask.Len(), s.Len()))
+	}
+
+	return s.Take(mask.TruePositions()...)
+}
+
+// TruePositions returns the positions of a boolean series which are true and not NA
+func (s Series) TruePositions() []int {
+	if s.t != Boolean {
+		panic(fmt.Errorf("mask must be of type %v, but got %v", Boolean, s.t))
+	}
+
+	var positions []int
+	for i := 0; i < s.Len(); i++ {
+		if !s.Elem(i).IsNA() && s.Val(i).(bool) {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
 // Head returns a slice of the first n elements of the series
 func (s Series) Head(n int) Series {
 	return s.Slice(0, n)
@@ -274,98 +431,47 @@ func (s Series) Tail(n int) Series {
 	return s.Slice(s.Len()-n, s.Len())
 }
 
-// Sort sorts the series in place via bubble sort TODO: replace with merge sort later
+// Sort sorts the series in place in ascending order, with NA values placed last
 func (s Series) Sort() {
-	n := s.Len()
-	for i := 0; i < n; i++ {
-		for j := 0; j < n-i-1; j++ {
-			swap := false
-			switch s.t {
-			case Int:
-				swap = s.Val(j).(int) > s.Val(j+1).(int)
-			case Float:
-				swap = s.Val(j).(float64) > s.Val(j+1).(float64)
-			case Boolean:
-				swap = s.Val(j).(bool) && !s.Val(j+1).(bool)
-			case String:
-				panic("not implemented")
-			case Runic:
-				panic("not implemented")
-			}
-
-			if swap {
-				temp := s.Val(j)
-				s.Elem(j).Set(s.Val(j + 1))
-				s.Elem(j + 1).Set(temp)
-			}
-		}
-	}
+	s.Order(s.SortedIndex()...)
 }
 
-// SortedIndex returns the indices of the series sorted in ascending order
+// SortedIndex returns the indices of the series sorted in ascending order, with NA values placed last
 func (s Series) SortedIndex() []int {
-	n := s.Len()
-	index := make([]int, n)
-	for i := 0; i < n; i++ {
-		index[i] = i
-	}
-
-	// Bubble Sort TODO: replace with more efficient sort such as merge sort
-	for i := 0; i < n; i++ {
-		for j := 0; j < n-i-1; j++ {
-			swap := false
-			switch s.t {
-			case Int:
-				swap = s.Val(index[j]).(int) > s.Val(index[j+1]).(int)
-			case Float:
-				swap = s.Val(index[j]).(float64) > s.Val(index[j+1]).(float64)
-			case Boolean:
-				swap = s.Val(index[j]).(bool) && !s.Val(index[j+1]).(bool)
-			case String:
-				panic("not implemented")
-			case Runic:
-				panic("not implemented")
-			}
-			if swap {
-				index[j], index[j+1] = index[j+1], index[j]
-			}
-		}
-	}
-
-	return index
+	return s.sortedIndex(SortSettings{})
 }
 
-// Order returns the series with the elements ordered according to the positions slice
+// Order returns the series with the elements ordered according to the positions slice, such that element i of the
+// result is element positions[i] of the original series. The series is reordered in place
 func (s Series) Order(positions ...int) Series {
 	if len(positions) != s.Len() {
 		panic(fmt.Errorf("series and new positions must be the same length"))
 	}
 
-	// Need to copy otherwise positions collection will mutate
-	newPositions := make([]int, s.Len())
-	for i, pos := range positions {
-		newPositions[i] = pos
-	}
-
-	for newPos, oldPos := range newPositions {
-		if oldPos == newPos {
-			continue
-		}
-
-		temp := s.Val(oldPos)
-		s.Elem(oldPos).Set(s.Val(newPos))
-		s.Elem(newPos).Set(temp)
+	s.assign(s.Take(positions...))
+	return s
+}
 
-		for i, pos := range newPositions {
-			if pos == newPos {
-				newPositions[i] = oldPos
-				newPositions[newPos] = newPos
-				break
-			}
-		}
+// assign overwrites the elements of the series in place with the elements of other, which must share its type and length
+func (s Series) assign(other Series) {
+	switch e := s.elements.(type) {
+	case intElements:
+		copy(e, other.elements.(intElements))
+	case floatElements:
+		copy(e, other.elements.(floatElements))
+	case booleanElements:
+		copy(e, other.elements.(booleanElements))
+	case stringElements:
+		copy(e, other.elements.(stringElements))
+	case datetimeElements:
+		copy(e, other.elements.(datetimeElements))
+	case runicElements:
+		copy(e, other.elements.(runicElements))
+	case categoricalElements:
+		copy(e.elements, other.elements.(categoricalElements).elements)
+	default:
+		panic(fmt.Errorf("type %v not supported", s.t))
 	}
-
-	return s
 }
 
 // Count returns the number of occurrences of the value v in the series
@@ -391,6 +497,29 @@ func (s Series) Unique() bool {
 	return true
 }
 
+// UniqueValues returns a new series of the distinct values of the series in order of first appearance, with NA
+// values kept as a single NA element
+func (s Series) UniqueValues() Series {
+	seen := make(map[any]struct{})
+	seenNA := false
+	var positions []int
+	for i := 0; i < s.Len(); i++ {
+		if s.Elem(i).IsNA() {
+			if !seenNA {
+				seenNA = true
+				positions = append(positions, i)
+			}
+			continue
+		}
+
+		if _, ok := seen[s.Val(i)]; !ok {
+			seen[s.Val(i)] = struct{}{}
+			positions = append(positions, i)
+		}
+	}
+	return s.Take(positions...)
+}
+
 // Homogeneous returns true if there is only one value in the series
 func (s Series) Homogeneous() bool {
 	if s.Len() == 0 {
@@ -435,9 +564,9 @@ func (s Series) IsNumeric() bool {
 	return s.t == Int || s.t == Float || s.t == Boolean
 }
 
-// IsObject returns true if the series is of a non-numeric type (string, rune, object)
+// IsObject returns true if the series is of a non-numeric type (string, rune, category, object)
 func (s Series) IsObject() bool {
-	return s.t == String || s.t == Runic
+	return s.t == String || s.t == Runic || s.t == Categorical
 }
 
 // Mode returns the most frequent value in the series
@@ -455,31 +584,26 @@ func (s Series) Mode() any {
 	return mode
 }
 
-// Mean returns the mean of the series
+// Mean returns the mean of the non-NA values of the series
 func (s Series) Mean() float64 {
 	if !s.IsNumeric() {
 		panic(fmt.Errorf("mean is only supported for numeric types"))
 	}
 
+	values := s.Floats()
 	var sum float64
-	for i := 0; i < s.Len(); i++ {
-		switch s.t {
-		case Int:
-			sum += float64(s.Val(i).(int))
-		case Float:
-			sum += s.Val(i).(float64)
-		case Boolean:
-			if s.Val(i).(bool) {
-				sum++
-			}
-		}
+	for _, v := range values {
+		sum += v
 	}
 
-	return sum / float64(s.Len())
+	return sum / float64(len(values))
 }
 
-// Quantile returns the specified quantile of the series
-func (s Series) Quantile(q float64) any {
+// Quantile returns the specified quantile of the non-NA values of the series. By default, interpolation is "index",
+// which returns the value at position n * q of the n sorted values, keeping the type of the series. Otherwise, it
+// chooses between the two values the quantile lies between, and is one of "linear" or "midpoint" which return a
+// float64, or "lower", "higher" or "nearest" which return one of the values of the series
+func (s Series) Quantile(q float64, interpolation ...string) any {
 	if !s.IsNumeric() {
 		panic(fmt.Errorf("quantile is only supported for numeric types"))
 	}
@@ -488,13 +612,72 @@ func (s Series) Quantile(q float64) any {
 		panic(fmt.Errorf("quantile must be between 0 and 1, but got %v", q))
 	}
 
-	se := s.Copy()
+	if len(interpolation) > 1 {
+		panic(fmt.Errorf("only one interpolation allowed"))
+	}
+	if len(interpolation) == 0 {
+		interpolation = []string{"index"}
+	}
+
+	se := s.DropNA()
+	if se.Len() == 0 {
+		panic(fmt.Errorf("cannot take the quantile of a series with no values"))
+	}
 	se.Sort()
-	index := int(float64(s.Len()) * q)
-	return se.Val(index)
+
+	position := float64(se.Len()-1) * q
+	lower := int(math.Floor(position))
+	higher := int(math.Ceil(position))
+
+	switch interpolation[0] {
+	case "index":
+		index := int(float64(se.Len()) * q)
+		if index == se.Len() {
+			index--
+		}
+		return se.Val(index)
+	case "linear":
+		a, b := ToFloat(se.Val(lower)), ToFloat(se.Val(higher))
+		return a + (b-a)*(position-float64(lower))
+	case "midpoint":
+		return (ToFloat(se.Val(lower)) + ToFloat(se.Val(higher))) / 2
+	case "lower":
+		return se.Val(lower)
+	case "higher":
+		return se.Val(higher)
+	case "nearest":
+		return se.Val(int(math.RoundToEven(position)))
+	default:
+		panic(fmt.Errorf("interpolation must be one of %v, but got %v", []string{"index", "linear", "lower", "higher", "nearest", "midpoint"}, interpolation[0]))
+	}
+}
+
+// Median returns the median of the non-NA values of the series, with the interpolation of Quantile
+func (s Series) Median(interpolation ...string) any {
+	return s.Quantile(0.5, interpolation...)
+}
+
+// Map returns a new series of the same type with f applied to the value of each non-NA element. NA values are kept as
+// NA, and values for which f returns nil become NA
+func (s Series) Map(f func(v any) any) Series {
+	return s.Apply(s.t, f)
 }
 
-// Median returns the median of the series
-func (s Series) Median() any {
-	return s.Quantile(0.5)
+// Apply returns a new series of type t with f applied to the value of each non-NA element, where the values returned
+// by f are converted to type t as by Element.Set. NA values are kept as NA, and values for which f returns nil become
+// NA
+func (s Series) Apply(t Type, f func(v any) any) Series {
+	result := NewEmptySeries(t, s.Len(), s.Name)
+	if t == s.t {
+		result = NewEmptySeriesLike(s, s.Len(), s.Name)
+	}
+
+	for i := 0; i < s.Len(); i++ {
+		if s.Elem(i).IsNA() {
+			result.Elem(i).Set(nil)
+			continue
+		}
+		result.Elem(i).Set(f(s.Val(i)))
+	}
+	return result
 }
@@ -416,6 +416,43 @@ func TestSeries_Quantile(t *testing.T) {
 	if q != 2 {
 		t.Errorf("Expected:\n%v\nGot:\n%v", 2, q)
 	}
+
+	q = s.Quantile(1)
+	if q != 5 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 5, q)
+	}
+}
+
+func TestSeries_QuantileInterpolation(t *testing.T) {
+	s := New([]int{4, 1, 3, 2}, Int, "Integers")
+	expected := map[string]any{
+		"index":    3,
+		"linear":   2.5,
+		"midpoint": 2.5,
+		"lower":    2,
+		"higher":   3,
+		"nearest":  3,
+	}
+
+	for interpolation, e := range expected {
+		q := s.Quantile(0.5, interpolation)
+		if q != e {
+			t.Errorf("Expected %v quantile to be %v, got %v", interpolation, e, q)
+		}
+	}
+
+	q := s.Quantile(0.4, "linear")
+	if math.Abs(q.(float64)-2.2) > 1e-12 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 2.2, q)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Quantile to panic, but it did not")
+		}
+	}()
+
+	s.Quantile(0.5, "not a valid interpolation")
 }
 
 func TestSeries_Median(t *testing.T) {
@@ -426,4 +463,102 @@ func TestSeries_Median(t *testing.T) {
 		t.Errorf("Expected:\n%v\nGot:\n%v", 3, median)
 	}
 	// Output: 3
+
+	s = New([]float64{1, 2, math.NaN(), 3, 4}, Float, "Floats")
+	median = s.Median()
+
+	if median != 3.0 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 3.0, median)
+	}
+
+	median = s.Median("linear")
+	if median != 2.5 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 2.5, median)
+	}
+}
+
+func TestSeries_Take(t *testing.T) {
+	expected := "{Integers [3 1 1] int}"
+	s := New([]int{1, 2, 3}, Int, "Integers")
+	se := s.Take(2, 0, 0)
+
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	s = New([]float64{1.1, math.NaN(), 3.3}, Float, "Floats")
+	se = s.Take(1)
+
+	if !se.Elem(0).IsNA() {
+		t.Errorf("Expected NA to be preserved, got %v", se.Val(0))
+	}
+}
+
+func TestSeries_Filter(t *testing.T) {
+	expected := "{Strings [a c] string}"
+	s := New([]string{"a", "b", "c"}, String, "Strings")
+	se := s.Filter(New([]bool{true, false, true}, Boolean, "Mask"))
+
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+}
+
+func TestSeries_DropNA(t *testing.T) {
+	expected := "{Floats [1.1 3.3] float}"
+	s := New([]float64{1.1, math.NaN(), 3.3}, Float, "Floats")
+	se := s.DropNA()
+
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+}
+
+func TestSeries_TakeNA(t *testing.T) {
+	expected := "{Integers [2 NaN] int}"
+	s := New([]int{1, 2, 3}, Int, "Integers")
+	se := s.Take(1, -1)
+
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+}
+
+func TestSeries_UniqueValues(t *testing.T) {
+	expected := "{Floats [3 1 NaN 2] float}"
+	s := New([]float64{3, 1, math.NaN(), 3, 2, math.NaN(), 1}, Float, "Floats")
+	unique := s.UniqueValues()
+
+	if unique.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, unique.String())
+	}
+}
+
+func TestSeries_Map(t *testing.T) {
+	s := New([]int{1, 2, 3, 4}, Int, "Ints")
+	s.Elem(1).Set(nil)
+	expected := "{Ints [10 NaN NaN 40] int}"
+
+	result := s.Map(func(v any) any {
+		if v.(int) == 3 {
+			return nil
+		}
+		return v.(int) * 10
+	})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
+func TestSeries_Apply(t *testing.T) {
+	s := New([]string{"a", "bb", "ccc"}, String, "Strings")
+	s.Elem(2).Set(nil)
+	expected := "{Strings [1 2 NaN] int}"
+
+	result := s.Apply(Int, func(v any) any { return len(v.(string)) })
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
 }
@@ -0,0 +1,163 @@
+package series
+
+import (
+	"fmt"
+	"time"
+)
+
+// SortSettings defines a struct that contains settings for sorting a series, allows for optional settings
+type SortSettings struct {
+	Descending bool
+	// NAPosition is either "last" (the default) or "first"
+	NAPosition string
+}
+
+// ordered is the set of types which can be compared with the < operator
+type ordered interface {
+	~int | ~int32 | ~float64 | ~string
+}
+
+// compareOrdered returns -1, 0 or 1 if a is less than, equal to or greater than b
+func compareOrdered[T ordered](a, b T) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// CompareValues returns -1, 0 or 1 if a is ordered before, equal to or after b, where a and b share one of the
+// element value types
+func CompareValues(a, b any) int {
+	switch a_ := a.(type) {
+	case int:
+		return compareOrdered(a_, b.(int))
+	case float64:
+		return compareOrdered(a_, b.(float64))
+	case string:
+		return compareOrdered(a_, b.(string))
+	case rune:
+		return compareOrdered(a_, b.(rune))
+	case time.Time:
+		return a_.Compare(b.(time.Time))
+	case bool:
+		b_ := b.(bool)
+		if a_ == b_ {
+			return 0
+		}
+		if b_ {
+			return -1
+		}
+		return 1
+	default:
+		panic(fmt.Errorf("cannot compare values of type %T", a))
+	}
+}
+
+// Compare returns -1, 0 or 1 if the value of element i is ordered before, equal to or after the value of element j.
+// NA values are not considered, their underlying values are compared. Categorical values are ordered by the order of
+// their categories
+func (s Series) Compare(i, j int) int {
+	if e, ok := s.elements.(categoricalElements); ok {
+		return compareOrdered(e.elements[i].code, e.elements[j].code)
+	}
+	return CompareValues(s.Val(i), s.Val(j))
+}
+
+// Argsort returns the positions 0 to n-1 ordered by less. The sort is a stable merge sort, so positions which are
+// not less than one another keep their original order
+func Argsort(n int, less func(i, j int) bool) []int {
+	index := make([]int, n)
+	for i := range index {
+		index[i] = i
+	}
+
+	mergeSort(index, make([]int, n), less)
+	return index
+}
+
+// mergeSort sorts index in place by less, using buffer as scratch space of the same length
+func mergeSort(index, buffer []int, less func(i, j int) bool) {
+	n := len(index)
+	if n <= 12 {
+		// Insertion sort is faster for short runs and is also stable
+		for i := 1; i < n; i++ {
+			for j := i; j > 0 && less(index[j], index[j-1]); j-- {
+				index[j], index[j-1] = index[j-1], index[j]
+			}
+		}
+		return
+	}
+
+	mid := n / 2
+	mergeSort(index[:mid], buffer[:mid], less)
+	mergeSort(index[mid:], buffer[mid:], less)
+
+	// Halves are already in order
+	if !less(index[mid], index[mid-1]) {
+		return
+	}
+
+	copy(buffer, index)
+	i, j, k := 0, mid, 0
+	for i < mid && j < n {
+		if less(buffer[j], buffer[i]) {
+			index[k] = buffer[j]
+			j++
+		} else {
+			index[k] = buffer[i]
+			i++
+		}
+		k++
+	}
+
+	// Any remaining right half elements are already in place
+	copy(index[k:], buffer[i:mid])
+}
+
+// naLess wraps a comparison of non-NA values into an ordering of a series which places NA values first or last
+func naLess(s Series, naFirst bool, compare func(i, j int) int) func(i, j int) bool {
+	return func(i, j int) bool {
+		naI, naJ := s.Elem(i).IsNA(), s.Elem(j).IsNA()
+		if naI || naJ {
+			if naI == naJ {
+				return false
+			}
+			return naI == naFirst
+		}
+		return compare(i, j) < 0
+	}
+}
+
+// sortedIndex returns the positions of the series in sorted order
+func (s Series) sortedIndex(settings SortSettings) []int {
+	naFirst := false
+	switch settings.NAPosition {
+	case "", "last":
+	case "first":
+		naFirst = true
+	default:
+		panic(fmt.Errorf("NA position must be one of %v, but got %v", []string{"first", "last"}, settings.NAPosition))
+	}
+
+	compare := s.Compare
+	if settings.Descending {
+		compare = func(i, j int) int { return s.Compare(j, i) }
+	}
+	return Argsort(s.Len(), naLess(s, naFirst, compare))
+}
+
+// SortValues returns a sorted copy of the series, leaving the original series unchanged. The sort is stable, and NA
+// values are placed last unless specified otherwise
+func (s Series) SortValues(settings ...SortSettings) Series {
+	if len(settings) > 1 {
+		panic(fmt.Errorf("only one settings struct allowed"))
+	}
+	if len(settings) == 0 {
+		settings = append(settings, SortSettings{})
+	}
+
+	return s.Take(s.sortedIndex(settings[0])...)
+}
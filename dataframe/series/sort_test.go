@@ -0,0 +1,96 @@
+package series
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSeries_SortString(t *testing.T) {
+	expected := "{Strings [abc abd b] string}"
+	s := New([]string{"b", "abd", "abc"}, String, "Strings")
+	s.Sort()
+
+	if s.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.String())
+	}
+}
+
+func TestSeries_SortBool(t *testing.T) {
+	expected := "{Booleans [false false true true] bool}"
+	s := New([]bool{true, false, true, false}, Boolean, "Booleans")
+	s.Sort()
+
+	if s.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.String())
+	}
+}
+
+func TestSeries_SortNA(t *testing.T) {
+	expected := "{Floats [1.1 2.2 NaN] float}"
+	s := New([]float64{2.2, math.NaN(), 1.1}, Float, "Floats")
+	s.Sort()
+
+	if s.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, s.String())
+	}
+}
+
+func TestSeries_SortValues(t *testing.T) {
+	expected := "{Floats [NaN 3.3 2.2 1.1] float}"
+	s := New([]float64{2.2, math.NaN(), 3.3, 1.1}, Float, "Floats")
+	se := s.SortValues(SortSettings{Descending: true, NAPosition: "first"})
+
+	if se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	// The original series should not be modified
+	if s.Val(0) != 2.2 {
+		t.Errorf("Expected original series to be unchanged, got %v", s.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected SortValues to panic, but it did not")
+		}
+	}()
+
+	s.SortValues(SortSettings{NAPosition: "middle"})
+}
+
+func TestSeries_SortedIndexStable(t *testing.T) {
+	expected := []int{1, 3, 0, 2, 4}
+	s := New([]int{2, 1, 2, 1, 3}, Int, "Integers")
+	index := s.SortedIndex()
+
+	for i := range expected {
+		if index[i] != expected[i] {
+			t.Errorf("Expected:\n%v\nGot:\n%v", expected, index)
+			break
+		}
+	}
+}
+
+func TestArgsort(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = r.Intn(50)
+	}
+
+	index := Argsort(len(values), func(i, j int) bool { return values[i] < values[j] })
+
+	for i := 1; i < len(index); i++ {
+		a, b := values[index[i-1]], values[index[i]]
+		if a > b || (a == b && index[i-1] > index[i]) {
+			t.Fatalf("Expected stable ascending order at position %v, got %v (%v) before %v (%v)", i, a, index[i-1], b, index[i])
+		}
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	if CompareValues(1, 2) != -1 || CompareValues("b", "a") != 1 || CompareValues(false, true) != -1 || CompareValues(1.5, 1.5) != 0 {
+		t.Errorf("Unexpected comparison result")
+	}
+}
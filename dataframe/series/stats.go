@@ -0,0 +1,145 @@
+package series
+
+import (
+	"fmt"
+	"math"
+)
+
+// ToFloat converts a numeric element value, an int, float64 or bool, to a float64
+func ToFloat(v any) float64 {
+	switch v_ := v.(type) {
+	case int:
+		return float64(v_)
+	case float64:
+		return v_
+	case bool:
+		if v_ {
+			return 1
+		}
+		return 0
+	default:
+		panic(fmt.Errorf("value %v of type %T is not numeric", v, v))
+	}
+}
+
+// Floats returns the non-NA values of a numeric series as a collection of float64
+func (s Series) Floats() []float64 {
+	if !s.IsNumeric() {
+		panic(fmt.Errorf("series of type %v is not numeric", s.t))
+	}
+
+	values := make([]float64, 0, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		if !s.Elem(i).IsNA() {
+			values = append(values, ToFloat(s.Val(i)))
+		}
+	}
+	return values
+}
+
+// Sum returns the sum of the non-NA values of the series
+func (s Series) Sum() float64 {
+	sum := 0.0
+	for _, v := range s.Floats() {
+		sum += v
+	}
+	return sum
+}
+
+// extreme returns the non-NA value of the series which is ordered first according to sign, or nil if every value is NA
+func (s Series) extreme(sign int) any {
+	position := -1
+	for i := 0; i < s.Len(); i++ {
+		if s.Elem(i).IsNA() {
+			continue
+		}
+		if position == -1 || s.Compare(i, position) == sign {
+			position = i
+		}
+	}
+
+	if position == -1 {
+		return nil
+	}
+	return s.Val(position)
+}
+
+// Min returns the smallest non-NA value of the series, or nil if every value is NA
+func (s Series) Min() any {
+	return s.extreme(-1)
+}
+
+// Max returns the largest non-NA value of the series, or nil if every value is NA
+func (s Series) Max() any {
+	return s.extreme(1)
+}
+
+// moments returns the number of non-NA values, their mean and their central moments of order 2, 3 and 4
+func (s Series) moments() (n float64, mean float64, m2 float64, m3 float64, m4 float64) {
+	values := s.Floats()
+	n = float64(len(values))
+	for _, v := range values {
+		mean += v
+	}
+	mean /= n
+
+	for _, v := range values {
+		d := v - mean
+		m2 += d * d
+		m3 += d * d * d
+		m4 += d * d * d * d
+	}
+	return n, mean, m2 / n, m3 / n, m4 / n
+}
+
+// Var returns the variance of the non-NA values of the series with ddof delta degrees of freedom, which defaults to 1
+// for the sample variance. NaN is returned if there are not more values than ddof
+func (s Series) Var(ddof ...int) float64 {
+	if len(ddof) > 1 {
+		panic(fmt.Errorf("only one ddof allowed"))
+	}
+	if len(ddof) == 0 {
+		ddof = []int{1}
+	}
+
+	n, _, m2, _, _ := s.moments()
+	if n <= float64(ddof[0]) {
+		return math.NaN()
+	}
+	return m2 * n / (n - float64(ddof[0]))
+}
+
+// Std returns the standard deviation of the non-NA values of the series with ddof delta degrees of freedom, which
+// defaults to 1 for the sample standard deviation
+func (s Series) Std(ddof ...int) float64 {
+	return math.Sqrt(s.Var(ddof...))
+}
+
+// Skew returns the unbiased skewness of the non-NA values of the series, or NaN if there are fewer than 3 values
+func (s Series) Skew() float64 {
+	n, _, m2, m3, _ := s.moments()
+	if n < 3 {
+		return math.NaN()
+	}
+	if m2 == 0 {
+		return 0
+	}
+
+	g1 := m3 / math.Pow(m2, 1.5)
+	return math.Sqrt(n*(n-1)) / (n - 2) * g1
+}
+
+// Kurtosis returns the unbiased excess kurtosis of the non-NA values of the series, or NaN if there are fewer than 4
+// values
+func (s Series) Kurtosis() float64 {
+	n, _, m2, _, m4 := s.moments()
+	if n < 4 {
+		return math.NaN()
+	}
+	if m2 == 0 {
+		return 0
+	}
+
+	g2 := m4/(m2*m2) - 3
+	return (n - 1) / ((n - 2) * (n - 3)) * ((n+1)*g2 + 6)
+}
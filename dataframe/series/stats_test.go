@@ -0,0 +1,84 @@
+package series
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSeries_Sum(t *testing.T) {
+	s := New([]float64{1.5, 2.5, math.NaN(), 3}, Float, "Floats")
+	sum := s.Sum()
+
+	if sum != 7 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 7, sum)
+	}
+}
+
+func TestSeries_MinMax(t *testing.T) {
+	s := New([]int{3, 1, 4, 1, 5}, Int, "Integers")
+
+	if s.Min() != 1 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 1, s.Min())
+	}
+	if s.Max() != 5 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 5, s.Max())
+	}
+
+	s = New([]string{"b", "c", "a"}, String, "Strings")
+
+	if s.Min() != "a" {
+		t.Errorf("Expected:\n%v\nGot:\n%v", "a", s.Min())
+	}
+	if s.Max() != "c" {
+		t.Errorf("Expected:\n%v\nGot:\n%v", "c", s.Max())
+	}
+}
+
+func TestSeries_VarStd(t *testing.T) {
+	s := New([]int{2, 4, 4, 4, 5, 5, 7, 9}, Int, "Integers")
+
+	if s.Var(0) != 4 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 4, s.Var(0))
+	}
+	if s.Std(0) != 2 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 2, s.Std(0))
+	}
+	if math.Abs(s.Var()-32.0/7.0) > 1e-12 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 32.0/7.0, s.Var())
+	}
+
+	s = New([]int{1}, Int, "Integers")
+	if !math.IsNaN(s.Std()) {
+		t.Errorf("Expected:\n%v\nGot:\n%v", math.NaN(), s.Std())
+	}
+}
+
+func TestSeries_Skew(t *testing.T) {
+	s := New([]float64{1, 2, 3, 4, 10}, Float, "Floats")
+	expected := 1.6971
+	skew := s.Skew()
+
+	if math.Abs(skew-expected) > 1e-4 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, skew)
+	}
+
+	s = New([]float64{1, 2, 3}, Float, "Floats")
+	if s.Skew() != 0 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", 0, s.Skew())
+	}
+}
+
+func TestSeries_Kurtosis(t *testing.T) {
+	s := New([]float64{1, 2, 3, 4, 10}, Float, "Floats")
+	expected := 3.152
+	kurtosis := s.Kurtosis()
+
+	if math.Abs(kurtosis-expected) > 1e-4 {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, kurtosis)
+	}
+
+	s = New([]float64{1, 2, 3}, Float, "Floats")
+	if !math.IsNaN(s.Kurtosis()) {
+		t.Errorf("Expected:\n%v\nGot:\n%v", math.NaN(), s.Kurtosis())
+	}
+}
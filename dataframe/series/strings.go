@@ -0,0 +1,184 @@
+package series
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// StringMethods provides string operations on the values of a string series. Every operation returns a new series,
+// and NA values are kept as NA
+type StringMethods struct {
+	s Series
+}
+
+// Str returns the string methods of a string series
+func (s Series) Str() StringMethods {
+	if s.t != String {
+		panic(fmt.Errorf("string methods are only supported for type %v, but got %v", String, s.t))
+	}
+	return StringMethods{s: s}
+}
+
+// apply returns a series of type t with f applied to each non-NA value
+func (m StringMethods) apply(t Type, name string, f func(v string) any) Series {
+	result := NewEmptySeries(t, m.s.Len(), name)
+	for i := 0; i < m.s.Len(); i++ {
+		if m.s.Elem(i).IsNA() {
+			result.Elem(i).Set(nil)
+			continue
+		}
+		result.Elem(i).Set(f(m.s.Val(i).(string)))
+	}
+	return result
+}
+
+// transform returns a string series with f applied to each non-NA value
+func (m StringMethods) transform(f func(v string) string) Series {
+	return m.apply(String, m.s.Name, func(v string) any { return f(v) })
+}
+
+// test returns a boolean series of f applied to each non-NA value
+func (m StringMethods) test(f func(v string) bool) Series {
+	return m.apply(Boolean, m.s.Name, func(v string) any { return f(v) })
+}
+
+// compile compiles a regular expression, panicking if it is invalid
+func compile(pattern string) *regexp.Regexp {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		panic(fmt.Errorf("invalid regular expression %v: %v", pattern, err))
+	}
+	return re
+}
+
+// Lower returns the values converted to lower case
+func (m StringMethods) Lower() Series {
+	return m.transform(strings.ToLower)
+}
+
+// Upper returns the values converted to upper case
+func (m StringMethods) Upper() Series {
+	return m.transform(strings.ToUpper)
+}
+
+// Strip returns the values with leading and trailing white space removed
+func (m StringMethods) Strip() Series {
+	return m.transform(strings.TrimSpace)
+}
+
+// Len returns an int series of the number of characters in each value
+func (m StringMethods) Len() Series {
+	return m.apply(Int, m.s.Name, func(v string) any { return utf8.RuneCountInString(v) })
+}
+
+// Contains returns a boolean series of whether each value contains substr
+func (m StringMethods) Contains(substr string) Series {
+	return m.test(func(v string) bool { return strings.Contains(v, substr) })
+}
+
+// StartsWith returns a boolean series of whether each value begins with prefix
+func (m StringMethods) StartsWith(prefix string) Series {
+	return m.test(func(v string) bool { return strings.HasPrefix(v, prefix) })
+}
+
+// EndsWith returns a boolean series of whether each value ends with suffix
+func (m StringMethods) EndsWith(suffix string) Series {
+	return m.test(func(v string) bool { return strings.HasSuffix(v, suffix) })
+}
+
+// Match returns a boolean series of whether the regular expression pattern matches any part of each value. Anchor
+// the pattern with ^ and $ to match whole values
+func (m StringMethods) Match(pattern string) Series {
+	re := compile(pattern)
+	return m.test(re.MatchString)
+}
+
+// Replace returns the values with every occurrence of old replaced by new
+func (m StringMethods) Replace(old, new string) Series {
+	return m.transform(func(v string) string { return strings.ReplaceAll(v, old, new) })
+}
+
+// ReplaceRegex returns the values with every match of the regular expression pattern replaced by repl, which may
+// refer to capture groups as in regexp.Regexp.ReplaceAllString
+func (m StringMethods) ReplaceRegex(pattern, repl string) Series {
+	re := compile(pattern)
+	return m.transform(func(v string) string { return re.ReplaceAllString(v, repl) })
+}
+
+// Slice returns the characters of each value from index start to index end, which are clamped to the length of the
+// value
+func (m StringMethods) Slice(start, end int) Series {
+	if start < 0 || start > end {
+		panic(fmt.Errorf("invalid slice %v to %v", start, end))
+	}
+
+	return m.transform(func(v string) string {
+		runes := []rune(v)
+		a, b := start, end
+		if a > len(runes) {
+			a = len(runes)
+		}
+		if b > len(runes) {
+			b = len(runes)
+		}
+		return string(runes[a:b])
+	})
+}
+
+// Split splits each value around sep, returning one string series per part named after the series and the position
+// of the part. Values with fewer parts than the longest value are NA in the remaining series
+func (m StringMethods) Split(sep string) []Series {
+	parts := make([][]string, m.s.Len())
+	n := 0
+	for i := range parts {
+		if m.s.Elem(i).IsNA() {
+			continue
+		}
+		parts[i] = strings.Split(m.s.Val(i).(string), sep)
+		if len(parts[i]) > n {
+			n = len(parts[i])
+		}
+	}
+
+	result := make([]Series, n)
+	for j := range result {
+		result[j] = NewEmptySeries(String, m.s.Len(), fmt.Sprintf("%v_%v", m.s.Name, j))
+		for i, p := range parts {
+			if j < len(p) {
+				result[j].Elem(i).Set(p[j])
+			} else {
+				result[j].Elem(i).Set(nil)
+			}
+		}
+	}
+	return result
+}
+
+// Extract matches the regular expression pattern against each value, returning one string series per capture group.
+// Named groups give the name of their series, other groups are named after the series and the number of the group,
+// counting from 1. Values which do not match, and optional groups which take no part in the match, are NA
+func (m StringMethods) Extract(pattern string) []Series {
+	re := compile(pattern)
+	if re.NumSubexp() == 0 {
+		panic(fmt.Errorf("pattern %v has no capture groups", pattern))
+	}
+
+	result := make([]Series, re.NumSubexp())
+	for j, name := range re.SubexpNames()[1:] {
+		group := j + 1
+		if name == "" {
+			name = fmt.Sprintf("%v_%v", m.s.Name, group)
+		}
+
+		result[j] = m.apply(String, name, func(v string) any {
+			match := re.FindStringSubmatchIndex(v)
+			if match == nil || match[2*group] == -1 {
+				return nil
+			}
+			return v[match[2*group]:match[2*group+1]]
+		})
+	}
+	return result
+}
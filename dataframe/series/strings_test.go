@@ -0,0 +1,134 @@
+package series
+
+import (
+	"testing"
+)
+
+func TestSeries_Str(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Str to panic, but it did not")
+		}
+	}()
+
+	New([]int{1, 2}, Int, "Integers").Str()
+}
+
+func TestStringMethods_Transform(t *testing.T) {
+	s := New([]string{"  Hello World ", "go-lang", "", "na"}, String, "Strings")
+	s.Elem(3).Set(nil)
+
+	tests := []struct {
+		name     string
+		got      Series
+		expected string
+	}{
+		{"Lower", s.Str().Lower(), "{Strings [  hello world  go-lang  NaN] string}"},
+		{"Upper", s.Str().Upper(), "{Strings [  HELLO WORLD  GO-LANG  NaN] string}"},
+		{"Strip", s.Str().Strip(), "{Strings [Hello World go-lang  NaN] string}"},
+		{"Replace", s.Str().Replace("o", "0"), "{Strings [  Hell0 W0rld  g0-lang  NaN] string}"},
+		{"ReplaceRegex", s.Str().ReplaceRegex(`(\w+)-(\w+)`, "$2-$1"), "{Strings [  Hello World  lang-go  NaN] string}"},
+		{"Slice", s.Str().Slice(2, 7), "{Strings [Hello -lang  NaN] string}"},
+		{"Len", s.Str().Len(), "{Strings [14 7 0 NaN] int}"},
+	}
+
+	for _, test := range tests {
+		if test.got.String() != test.expected {
+			t.Errorf("%v Expected:\n%v\nGot:\n%v", test.name, test.expected, test.got.String())
+		}
+	}
+}
+
+func TestStringMethods_Test(t *testing.T) {
+	s := New([]string{"  Hello World ", "go-lang", "", "na"}, String, "Strings")
+	s.Elem(3).Set(nil)
+
+	tests := []struct {
+		name     string
+		got      Series
+		expected string
+	}{
+		{"Contains", s.Str().Contains("World"), "{Strings [true false false NaN] bool}"},
+		{"StartsWith", s.Str().StartsWith("go"), "{Strings [false true false NaN] bool}"},
+		{"EndsWith", s.Str().EndsWith("lang"), "{Strings [false true false NaN] bool}"},
+		{"Match", s.Str().Match(`^\w+-\w+$`), "{Strings [false true false NaN] bool}"},
+	}
+
+	for _, test := range tests {
+		if test.got.String() != test.expected {
+			t.Errorf("%v Expected:\n%v\nGot:\n%v", test.name, test.expected, test.got.String())
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Match to panic, but it did not")
+		}
+	}()
+
+	s.Str().Match("(")
+}
+
+func TestStringMethods_Split(t *testing.T) {
+	s := New([]string{"a,b,c", "d", "e,f", ""}, String, "Letters")
+	s.Elem(3).Set(nil)
+	expected := []string{
+		"{Letters_0 [a d e NaN] string}",
+		"{Letters_1 [b NaN f NaN] string}",
+		"{Letters_2 [c NaN NaN NaN] string}",
+	}
+
+	parts := s.Str().Split(",")
+
+	if len(parts) != len(expected) {
+		t.Fatalf("Expected:\n%v\nGot:\n%v", len(expected), len(parts))
+	}
+	for i, part := range parts {
+		if part.String() != expected[i] {
+			t.Errorf("Expected:\n%v\nGot:\n%v", expected[i], part.String())
+		}
+	}
+}
+
+func TestStringMethods_Extract(t *testing.T) {
+	s := New([]string{"id-12 x", "id-7", "none"}, String, "Codes")
+	expected := []string{
+		"{prefix [id id NaN] string}",
+		"{Codes_2 [12 7 NaN] string}",
+	}
+
+	groups := s.Str().Extract(`(?P<prefix>[a-z]+)-(\d+)`)
+
+	if len(groups) != len(expected) {
+		t.Fatalf("Expected:\n%v\nGot:\n%v", len(expected), len(groups))
+	}
+	for i, group := range groups {
+		if group.String() != expected[i] {
+			t.Errorf("Expected:\n%v\nGot:\n%v", expected[i], group.String())
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Extract to panic, but it did not")
+		}
+	}()
+
+	s.Str().Extract(`\d+`)
+}
+
+func TestStringMethods_ExtractOptional(t *testing.T) {
+	s := New([]string{"12kg", "7", "x"}, String, "Weights")
+	expected := []string{
+		"{Weights_1 [12 7 NaN] string}",
+		"{Weights_2 [kg NaN NaN] string}",
+	}
+
+	groups := s.Str().Extract(`^(\d+)(kg)?$`)
+
+	for i, group := range groups {
+		if group.String() != expected[i] {
+			t.Errorf("Expected:\n%v\nGot:\n%v", expected[i], group.String())
+		}
+	}
+}
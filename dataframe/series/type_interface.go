@@ -3,6 +3,7 @@ package series
 import (
 	"fmt"
 	"math"
+	"time"
 )
 
 type intElement struct {
@@ -167,6 +168,8 @@ func (s *stringElement) Set(value any) {
 		s.e = v
 	case rune:
 		s.e = string(v)
+	case time.Time:
+		s.e = v.Format(time.RFC3339)
 	default:
 		s.nan = true
 		return
@@ -188,3 +191,48 @@ func (s stringElement) Type() Type {
 func (s stringElement) IsNumeric() bool {
 	return false
 }
+
+type runicElement struct {
+	e   rune
+	nan bool
+}
+
+// force implementation of Element interface
+var _ Element = (*runicElement)(nil)
+
+func (r *runicElement) Set(value any) {
+	r.nan = false
+
+	switch v := value.(type) {
+	case rune:
+		r.e = v
+	case int:
+		r.e = rune(v)
+	case string:
+		runes := []rune(v)
+		if len(runes) != 1 {
+			r.nan = true
+			return
+		}
+		r.e = runes[0]
+	default:
+		r.nan = true
+		return
+	}
+}
+
+func (r runicElement) Get() any {
+	return r.e
+}
+
+func (r runicElement) IsNA() bool {
+	return r.nan
+}
+
+func (r runicElement) Type() Type {
+	return Runic
+}
+
+func (r runicElement) IsNumeric() bool {
+	return false
+}
@@ -0,0 +1,418 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Window is a collection of windows over a numeric series, ending at each element of the series, which can be
+// aggregated into a float series of the same length. NA values are skipped, and a window with fewer than minPeriods
+// values gives NA
+type Window struct {
+	s          Series
+	size       int
+	minPeriods int
+}
+
+// Rolling returns windows of the last size elements of the series. The minimum number of values required in each
+// window defaults to size
+func (s Series) Rolling(size int, minPeriods ...int) Window {
+	if size < 1 {
+		panic(fmt.Errorf("window size must be greater than 0, but got %v", size))
+	}
+	if len(minPeriods) > 1 {
+		panic(fmt.Errorf("only one minPeriods allowed"))
+	}
+	if len(minPeriods) == 0 {
+		minPeriods = []int{size}
+	}
+
+	return newWindow(s, size, minPeriods[0])
+}
+
+// Expanding returns windows of every element of the series up to and including each element. The minimum number of
+// values required in each window defaults to 1
+func (s Series) Expanding(minPeriods ...int) Window {
+	if len(minPeriods) > 1 {
+		panic(fmt.Errorf("only one minPeriods allowed"))
+	}
+	if len(minPeriods) == 0 {
+		minPeriods = []int{1}
+	}
+
+	return newWindow(s, s.Len(), minPeriods[0])
+}
+
+// newWindow checks the series and minimum periods of a Window
+func newWindow(s Series, size int, minPeriods int) Window {
+	if !s.IsNumeric() {
+		panic(fmt.Errorf("window operations are only supported for numeric types"))
+	}
+	if minPeriods < 0 {
+		panic(fmt.Errorf("minPeriods must not be negative, but got %v", minPeriods))
+	}
+
+	return Window{s: s, size: size, minPeriods: minPeriods}
+}
+
+// Apply reduces the non-NA values of each window with f. Each window is gathered separately, so the built-in
+// statistics, which keep running totals, are faster where they apply
+func (w Window) Apply(f func(values []float64) float64) Series {
+	result := NewEmptySeries(Float, w.s.Len(), w.s.Name)
+	values := make([]float64, 0, w.size)
+	for i := 0; i < w.s.Len(); i++ {
+		values = values[:0]
+		start := i - w.size + 1
+		if start < 0 {
+			start = 0
+		}
+
+		for j := start; j <= i; j++ {
+			if !w.s.Elem(j).IsNA() {
+				values = append(values, ToFloat(w.s.Val(j)))
+			}
+		}
+
+		if len(values) < w.minPeriods || len(values) == 0 {
+			result.Elem(i).Set(nil)
+			continue
+		}
+		result.Elem(i).Set(f(values))
+	}
+	return result
+}
+
+// runningMoments holds the number, sum, mean and sum of squared deviations from the mean of the values of a window,
+// updated as values enter and leave the window. The sum is compensated for rounding, so values leaving the window do
+// not leave rounding errors behind
+type runningMoments struct {
+	n            int
+	sum          float64
+	compensation float64
+	mean         float64
+	m2           float64
+}
+
+// total adds v to the compensated sum
+func (m *runningMoments) total(v float64) {
+	t := m.sum + v
+	if math.Abs(m.sum) >= math.Abs(v) {
+		m.compensation += (m.sum - t) + v
+	} else {
+		m.compensation += (v - t) + m.sum
+	}
+	m.sum = t
+}
+
+// add adds a value entering the window
+func (m *runningMoments) add(v float64) {
+	m.n++
+	m.total(v)
+	delta := v - m.mean
+	m.mean += delta / float64(m.n)
+	m.m2 += delta * (v - m.mean)
+}
+
+// remove removes a value leaving the window
+func (m *runningMoments) remove(v float64) {
+	m.n--
+	if m.n == 0 {
+		*m = runningMoments{}
+		return
+	}
+	m.total(-v)
+	delta := v - m.mean
+	m.mean -= delta / float64(m.n)
+	m.m2 = math.Max(m.m2-delta*(v-m.mean), 0)
+}
+
+// running computes a statistic of each window from the running moments of its non-NA values, so each window costs
+// constant time. The statistic gives NA by returning nil
+func (w Window) running(f func(m runningMoments) any) Series {
+	result := NewEmptySeries(Float, w.s.Len(), w.s.Name)
+	var m runningMoments
+	for i := 0; i < w.s.Len(); i++ {
+		if !w.s.Elem(i).IsNA() {
+			m.add(ToFloat(w.s.Val(i)))
+		}
+		if j := i - w.size; j >= 0 && !w.s.Elem(j).IsNA() {
+			m.remove(ToFloat(w.s.Val(j)))
+		}
+
+		if m.n < w.minPeriods || m.n == 0 {
+			result.Elem(i).Set(nil)
+			continue
+		}
+		result.Elem(i).Set(f(m))
+	}
+	return result
+}
+
+// Count returns the number of non-NA values in each window
+func (w Window) Count() Series {
+	return w.running(func(m runningMoments) any { return float64(m.n) })
+}
+
+// Sum returns the sum of each window
+func (w Window) Sum() Series {
+	return w.running(func(m runningMoments) any { return m.sum + m.compensation })
+}
+
+// Mean returns the mean of each window
+func (w Window) Mean() Series {
+	return w.running(func(m runningMoments) any { return (m.sum + m.compensation) / float64(m.n) })
+}
+
+// Var returns the sample variance of each window, which is NA for windows with a single value
+func (w Window) Var() Series {
+	return w.running(func(m runningMoments) any {
+		if m.n < 2 {
+			return nil
+		}
+		return m.m2 / float64(m.n-1)
+	})
+}
+
+// Std returns the sample standard deviation of each window, which is NA for windows with a single value
+func (w Window) Std() Series {
+	return w.running(func(m runningMoments) any {
+		if m.n < 2 {
+			return nil
+		}
+		return math.Sqrt(m.m2 / float64(m.n-1))
+	})
+}
+
+// Min returns the smallest value of each window
+func (w Window) Min() Series {
+	return w.Apply(func(values []float64) float64 {
+		min := values[0]
+		for _, v := range values[1:] {
+			min = math.Min(min, v)
+		}
+		return min
+	})
+}
+
+// Max returns the largest value of each window
+func (w Window) Max() Series {
+	return w.Apply(func(values []float64) float64 {
+		max := values[0]
+		for _, v := range values[1:] {
+			max = math.Max(max, v)
+		}
+		return max
+	})
+}
+
+// Median returns the median of each window
+func (w Window) Median() Series {
+	return w.Apply(func(values []float64) float64 {
+		sorted := append([]float64{}, values...)
+		sort.Float64s(sorted)
+
+		n := len(sorted)
+		if n%2 == 1 {
+			return sorted[n/2]
+		}
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	})
+}
+
+// EWMMean returns the exponentially weighted moving mean of a numeric series with smoothing factor alpha, where
+// 0 < alpha <= 1. A span of n periods corresponds to an alpha of 2 / (n + 1). Each mean is the weighted average of
+// the non-NA values so far, with the weight of a value decaying by 1 - alpha for each later value. NA values are
+// skipped, and positions with fewer than minPeriods values so far, which defaults to 1, give NA
+func (s Series) EWMMean(alpha float64, minPeriods ...int) Series {
+	if alpha <= 0 || alpha > 1 {
+		panic(fmt.Errorf("alpha must be in (0, 1], but got %v", alpha))
+	}
+	if len(minPeriods) > 1 {
+		panic(fmt.Errorf("only one minPeriods allowed"))
+	}
+	if len(minPeriods) == 0 {
+		minPeriods = []int{1}
+	}
+	if !s.IsNumeric() {
+		panic(fmt.Errorf("window operations are only supported for numeric types"))
+	}
+
+	result := NewEmptySeries(Float, s.Len(), s.Name)
+	numerator, denominator := 0.0, 0.0
+	count := 0
+	for i := 0; i < s.Len(); i++ {
+		if !s.Elem(i).IsNA() {
+			numerator = ToFloat(s.Val(i)) + (1-alpha)*numerator
+			denominator = 1 + (1-alpha)*denominator
+			count++
+		}
+
+		if count < minPeriods[0] || count == 0 {
+			result.Elem(i).Set(nil)
+			continue
+		}
+		result.Elem(i).Set(numerator / denominator)
+	}
+	return result
+}
+
+// Shift returns a copy of the series with each element moved later by periods positions, or earlier if periods is
+// negative. Positions left empty are NA
+func (s Series) Shift(periods int) Series {
+	positions := make([]int, s.Len())
+	for i := range positions {
+		positions[i] = i - periods
+		if positions[i] < 0 || positions[i] >= s.Len() {
+			positions[i] = -1
+		}
+	}
+	return s.Take(positions...)
+}
+
+// Lag returns a copy of the series where each element holds the value n positions earlier
+func (s Series) Lag(n int) Series {
+	return s.Shift(n)
+}
+
+// Lead returns a copy of the series where each element holds the value n positions later
+func (s Series) Lead(n int) Series {
+	return s.Shift(-n)
+}
+
+// difference applies f to each value of a numeric series and the value periods positions earlier, giving a float
+// series which is NA where either value is NA
+func (s Series) difference(periods []int, f func(current, previous float64) float64) Series {
+	if len(periods) > 1 {
+		panic(fmt.Errorf("only one periods allowed"))
+	}
+	if len(periods) == 0 {
+		periods = []int{1}
+	}
+	if !s.IsNumeric() {
+		panic(fmt.Errorf("window operations are only supported for numeric types"))
+	}
+
+	previous := s.Shift(periods[0])
+	result := NewEmptySeries(Float, s.Len(), s.Name)
+	for i := 0; i < s.Len(); i++ {
+		if s.Elem(i).IsNA() || previous.Elem(i).IsNA() {
+			result.Elem(i).Set(nil)
+			continue
+		}
+		result.Elem(i).Set(f(ToFloat(s.Val(i)), ToFloat(previous.Val(i))))
+	}
+	return result
+}
+
+// Diff returns the difference between each value and the value periods positions earlier, which defaults to 1
+func (s Series) Diff(periods ...int) Series {
+	return s.difference(periods, func(current, previous float64) float64 { return current - previous })
+}
+
+// PctChange returns the fractional change between each value and the value periods positions earlier, which
+// defaults to 1. A change from zero is NA
+func (s Series) PctChange(periods ...int) Series {
+	return s.difference(periods, func(current, previous float64) float64 { return current/previous - 1 })
+}
+
+// accumulate combines the non-NA values of a numeric series in order, giving a series of the running result which
+// keeps NA values as NA. Int and boolean series are combined as ints with ints and give an int series, and float
+// series are combined with floats and give a float series
+func (s Series) accumulate(ints func(total, v int) int, floats func(total, v float64) float64) Series {
+	if !s.IsNumeric() {
+		panic(fmt.Errorf("cumulative operations are only supported for numeric types"))
+	}
+
+	if s.t == Float {
+		result := NewEmptySeries(Float, s.Len(), s.Name)
+		var total float64
+		started := false
+		for i := 0; i < s.Len(); i++ {
+			if s.Elem(i).IsNA() {
+				result.Elem(i).Set(nil)
+				continue
+			}
+
+			v := s.Val(i).(float64)
+			if started {
+				total = floats(total, v)
+			} else {
+				total = v
+				started = true
+			}
+			result.Elem(i).Set(total)
+		}
+		return result
+	}
+
+	result := NewEmptySeries(Int, s.Len(), s.Name)
+	var total int
+	started := false
+	for i := 0; i < s.Len(); i++ {
+		if s.Elem(i).IsNA() {
+			result.Elem(i).Set(nil)
+			continue
+		}
+
+		var v int
+		switch val := s.Val(i).(type) {
+		case int:
+			v = val
+		case bool:
+			if val {
+				v = 1
+			}
+		}
+		if started {
+			total = ints(total, v)
+		} else {
+			total = v
+			started = true
+		}
+		result.Elem(i).Set(total)
+	}
+	return result
+}
+
+// CumSum returns the cumulative sum of the series
+func (s Series) CumSum() Series {
+	return s.accumulate(
+		func(total, v int) int { return total + v },
+		func(total, v float64) float64 { return total + v },
+	)
+}
+
+// CumProd returns the cumulative product of the series
+func (s Series) CumProd() Series {
+	return s.accumulate(
+		func(total, v int) int { return total * v },
+		func(total, v float64) float64 { return total * v },
+	)
+}
+
+// CumMax returns the cumulative maximum of the series
+func (s Series) CumMax() Series {
+	return s.accumulate(
+		func(total, v int) int {
+			if v > total {
+				return v
+			}
+			return total
+		},
+		math.Max,
+	)
+}
+
+// CumMin returns the cumulative minimum of the series
+func (s Series) CumMin() Series {
+	return s.accumulate(
+		func(total, v int) int {
+			if v < total {
+				return v
+			}
+			return total
+		},
+		math.Min,
+	)
+}
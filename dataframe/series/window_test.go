@@ -0,0 +1,176 @@
+package series
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSeries_Rolling(t *testing.T) {
+	s := New([]float64{1, 2, math.NaN(), 4, 5}, Float, "Floats")
+
+	tests := []struct {
+		name     string
+		got      Series
+		expected string
+	}{
+		{"Sum", s.Rolling(2).Sum(), "{Floats [NaN 3 NaN NaN 9] float}"},
+		{"SumMinPeriods", s.Rolling(2, 1).Sum(), "{Floats [1 3 2 4 9] float}"},
+		{"Mean", s.Rolling(3, 1).Mean(), "{Floats [1 1.5 1.5 3 4.5] float}"},
+		{"Count", s.Rolling(3, 0).Count(), "{Floats [1 2 2 2 2] float}"},
+		{"Min", s.Rolling(2, 1).Min(), "{Floats [1 1 2 4 4] float}"},
+		{"Max", s.Rolling(2, 1).Max(), "{Floats [1 2 2 4 5] float}"},
+		{"Median", s.Rolling(3, 2).Median(), "{Floats [NaN 1.5 1.5 3 4.5] float}"},
+		{"Std", s.Rolling(2, 1).Std(), "{Floats [NaN 0.7071067811865476 NaN NaN 0.7071067811865476] float}"},
+		{"Var", s.Rolling(2, 1).Var(), "{Floats [NaN 0.5 NaN NaN 0.5] float}"},
+	}
+
+	for _, test := range tests {
+		if test.got.String() != test.expected {
+			t.Errorf("%v Expected:\n%v\nGot:\n%v", test.name, test.expected, test.got.String())
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Rolling to panic, but it did not")
+		}
+	}()
+
+	New([]string{"a"}, String, "Strings").Rolling(1)
+}
+
+func TestWindow_RunningStatistics(t *testing.T) {
+	// The running statistics match the statistics of each window gathered separately
+	values := make([]float64, 200)
+	for i := range values {
+		values[i] = math.Sin(float64(i)) * 1000
+		if i%17 == 0 {
+			values[i] = math.NaN()
+		}
+	}
+	s := New(values, Float, "Floats")
+
+	for _, w := range []Window{s.Rolling(5, 2), s.Rolling(30), s.Expanding()} {
+		tests := []struct {
+			name     string
+			got      Series
+			expected Series
+		}{
+			{"Sum", w.Sum(), w.Apply(func(v []float64) float64 { return New(v, Float, "").Sum() })},
+			{"Mean", w.Mean(), w.Apply(func(v []float64) float64 { return New(v, Float, "").Mean() })},
+			{"Var", w.Var(), w.Apply(func(v []float64) float64 { return New(v, Float, "").Var() })},
+			{"Std", w.Std(), w.Apply(func(v []float64) float64 { return New(v, Float, "").Std() })},
+		}
+
+		for _, test := range tests {
+			for i := 0; i < s.Len(); i++ {
+				got, expected := test.got.Elem(i), test.expected.Elem(i)
+				if got.IsNA() != expected.IsNA() ||
+					(!got.IsNA() && math.Abs(got.Get().(float64)-expected.Get().(float64)) > 1e-9) {
+					t.Errorf("%v at %v Expected %v, got %v", test.name, i, expected.Get(), got.Get())
+				}
+			}
+		}
+	}
+}
+
+func TestSeries_Expanding(t *testing.T) {
+	s := New([]float64{1, 2, math.NaN(), 4, 5}, Float, "Floats")
+
+	expected := "{Floats [1 3 3 7 12] float}"
+	if se := s.Expanding().Sum(); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	expected = "{Floats [NaN NaN NaN 4 5] float}"
+	if se := s.Expanding(3).Max(); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+}
+
+func TestSeries_EWMMean(t *testing.T) {
+	s := New([]float64{1, math.NaN(), 3}, Float, "Floats")
+	expected := []float64{1, 1, 7.0 / 3}
+
+	// The weights of 1 and 3 are 0.5 and 1, as the NA value is skipped
+	se := s.EWMMean(0.5)
+	for i, e := range expected {
+		if math.Abs(se.Val(i).(float64)-e) > 1e-12 {
+			t.Errorf("Expected:\n%v\nGot:\n%v", e, se.Val(i))
+		}
+	}
+
+	expectedString := "{Floats [NaN NaN 2.3333333333333335] float}"
+	if se = s.EWMMean(0.5, 2); se.String() != expectedString {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expectedString, se.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected EWMMean to panic, but it did not")
+		}
+	}()
+
+	s.EWMMean(0)
+}
+
+func TestSeries_Shift(t *testing.T) {
+	s := New([]string{"a", "b", "c"}, String, "Strings")
+
+	expected := "{Strings [NaN a b] string}"
+	if se := s.Shift(1); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+	if se := s.Lag(1); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	expected = "{Strings [c NaN NaN] string}"
+	if se := s.Lead(2); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+}
+
+func TestSeries_DiffPctChange(t *testing.T) {
+	s := New([]int{2, 4, 0, 5, 10}, Int, "Integers")
+	s.Elem(2).Set(nil)
+
+	expected := "{Integers [NaN 2 NaN NaN 5] float}"
+	if se := s.Diff(); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+
+	expected = "{Integers [NaN NaN NaN 0.25 NaN] float}"
+	if se := s.PctChange(2); se.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, se.String())
+	}
+}
+
+func TestSeries_Cumulative(t *testing.T) {
+	s := New([]int{3, 1, 0, 4, 2}, Int, "Integers")
+	s.Elem(2).Set(nil)
+
+	// Ints beyond the exact range of floats keep their precision
+	large := New([]int{3037000499, 3037000499}, Int, "Large")
+
+	tests := []struct {
+		name     string
+		got      Series
+		expected string
+	}{
+		{"CumSum", s.CumSum(), "{Integers [3 4 NaN 8 10] int}"},
+		{"CumProd", s.CumProd(), "{Integers [3 3 NaN 12 24] int}"},
+		{"CumMax", s.CumMax(), "{Integers [3 3 NaN 4 4] int}"},
+		{"CumMin", s.CumMin(), "{Integers [3 1 NaN 1 1] int}"},
+		{"CumSumFloat", New([]float64{1, 2, math.NaN(), 4, 5}, Float, "Floats").CumSum(), "{Floats [1 3 NaN 7 12] float}"},
+		{"CumSumLarge", large.CumSum(), "{Large [3037000499 6074000998] int}"},
+		{"CumProdLarge", large.CumProd(), "{Large [3037000499 9223372030926249001] int}"},
+		{"CumSumBoolean", New([]bool{true, false, true}, Boolean, "Flags").CumSum(), "{Flags [1 1 2] int}"},
+	}
+
+	for _, test := range tests {
+		if test.got.String() != test.expected {
+			t.Errorf("%v Expected:\n%v\nGot:\n%v", test.name, test.expected, test.got.String())
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe/series"
+)
+
+// SortSettings defines a struct that contains settings for sorting a DataFrame, allows for optional settings
+type SortSettings struct {
+	// Descending sets the direction of each sort column, and defaults to ascending for every column if empty
+	Descending []bool
+	// NAPosition is either "last" (the default) or "first"
+	NAPosition string
+}
+
+// sortedIndex returns the positions of the rows of the DataFrame ordered by the specified columns. Later columns break
+// ties in earlier columns, and rows which tie on every column keep their original order.
+func (df DataFrame) sortedIndex(columns []string, settings SortSettings) []int {
+	if len(columns) == 0 {
+		panic("no columns specified")
+	}
+
+	descending := settings.Descending
+	if len(descending) == 0 {
+		descending = make([]bool, len(columns))
+	} else if len(descending) != len(columns) {
+		panic(fmt.Errorf("descending has length %v, expected %v", len(descending), len(columns)))
+	}
+
+	naFirst := false
+	switch settings.NAPosition {
+	case "", "last":
+	case "first":
+		naFirst = true
+	default:
+		panic(fmt.Errorf("NA position must be one of %v, but got %v", []string{"first", "last"}, settings.NAPosition))
+	}
+
+	keys := make([]series.Series, len(columns))
+	for k, name := range columns {
+		keys[k] = df.columns[df.columnPosition(name)]
+	}
+
+	return series.Argsort(df.nrows, func(i, j int) bool {
+		for k, s := range keys {
+			naI, naJ := s.Elem(i).IsNA(), s.Elem(j).IsNA()
+			if naI || naJ {
+				if naI == naJ {
+					continue
+				}
+				return naI == naFirst
+			}
+
+			c := s.Compare(i, j)
+			if descending[k] {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// SortValues returns a copy of the DataFrame sorted by the specified columns, leaving the original DataFrame
+// unchanged. The sort is stable, later columns break ties in earlier columns, and NA values are placed last unless
+// specified otherwise.
+func (df DataFrame) SortValues(columns []string, settings ...SortSettings) DataFrame {
+	if len(settings) > 1 {
+		panic(fmt.Errorf("only one settings struct allowed"))
+	}
+	if len(settings) == 0 {
+		settings = append(settings, SortSettings{})
+	}
+
+	return df.Take(df.sortedIndex(columns, settings[0])...)
+}
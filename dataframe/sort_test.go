@@ -0,0 +1,59 @@
+package dataframe
+
+import (
+	"github.com/chriso345/golab/dataframe/series"
+	"math"
+	"testing"
+)
+
+func TestDataFrame_SortMultipleColumns(t *testing.T) {
+	expected := "   Strings  Integers\n2        a         3\n0        a         1\n3        b         2\n1        b         1"
+
+	df := New(
+		series.New([]string{"a", "b", "a", "b"}, series.String, "Strings"),
+		series.New([]int{1, 1, 3, 2}, series.Int, "Integers"),
+	)
+	result := df.SortValues([]string{"Strings", "Integers"}, SortSettings{Descending: []bool{false, true}})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	// The original DataFrame should not be modified
+	if df.At(0, 1) != 1 || df.At(2, 1) != 3 {
+		t.Errorf("Expected original DataFrame to be unchanged, got\n%v", df.String())
+	}
+
+	df.Sort("Strings", "Integers")
+	expected = "   Strings  Integers\n0        a         1\n2        a         3\n1        b         1\n3        b         2"
+
+	if df.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, df.String())
+	}
+}
+
+func TestDataFrame_SortValuesNA(t *testing.T) {
+	expected := "   Floats\n1     NaN\n2     1.1\n0     2.2"
+
+	df := New(series.New([]float64{2.2, math.NaN(), 1.1}, series.Float, "Floats"))
+	result := df.SortValues([]string{"Floats"}, SortSettings{NAPosition: "first"})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	expected = "   Floats\n0     2.2\n2     1.1\n1     NaN"
+	result = df.SortValues([]string{"Floats"}, SortSettings{Descending: []bool{true}})
+
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected SortValues to panic, but it did not")
+		}
+	}()
+
+	df.SortValues([]string{"Floats"}, SortSettings{Descending: []bool{true, false}})
+}
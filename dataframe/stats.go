@@ -0,0 +1,190 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe/series"
+	"math"
+)
+
+// numericColumns returns the numeric columns of the DataFrame, panicking if there are none.
+func (df DataFrame) numericColumns() []series.Series {
+	var columns []series.Series
+	for _, s := range df.columns {
+		if s.IsNumeric() {
+			columns = append(columns, s)
+		}
+	}
+	if len(columns) == 0 {
+		panic(fmt.Errorf("DataFrame has no numeric columns"))
+	}
+	return columns
+}
+
+// Describe returns a summary of each numeric column of the DataFrame, indexed by the statistics count, mean, std,
+// min, 25%, 50%, 75% and max, where the percentiles are interpolated linearly. NA values are excluded from every
+// statistic.
+func (df DataFrame) Describe() DataFrame {
+	labels := []string{"count", "mean", "std", "min", "25%", "50%", "75%", "max"}
+
+	columns := df.numericColumns()
+	summary := make([]series.Series, len(columns))
+	for k, s := range columns {
+		summary[k] = series.NewEmptySeries(series.Float, len(labels), s.Name)
+
+		n := len(s.Floats())
+		summary[k].Elem(0).Set(n)
+		if n == 0 {
+			continue
+		}
+
+		summary[k].Elem(1).Set(s.Mean())
+		summary[k].Elem(2).Set(s.Std())
+		summary[k].Elem(3).Set(s.Min())
+		summary[k].Elem(4).Set(s.Quantile(0.25, "linear"))
+		summary[k].Elem(5).Set(s.Quantile(0.5, "linear"))
+		summary[k].Elem(6).Set(s.Quantile(0.75, "linear"))
+		summary[k].Elem(7).Set(s.Max())
+	}
+
+	return New(summary...).SetIndex(series.New(labels, series.String, "Index"))
+}
+
+// completePairs returns the values of rows where both a and b are not NA.
+func completePairs(a, b series.Series) ([]float64, []float64) {
+	var x, y []float64
+	for i := 0; i < a.Len(); i++ {
+		if a.Elem(i).IsNA() || b.Elem(i).IsNA() {
+			continue
+		}
+		x = append(x, series.ToFloat(a.Val(i)))
+		y = append(y, series.ToFloat(b.Val(i)))
+	}
+	return x, y
+}
+
+// pairwise builds a square matrix of the numeric columns of the DataFrame, where each value is computed by f from
+// the rows which are not NA in either column of the pair.
+func (df DataFrame) pairwise(f func(x, y []float64) float64) DataFrame {
+	columns := df.numericColumns()
+	names := make([]string, len(columns))
+	for i, s := range columns {
+		names[i] = s.Name
+	}
+
+	matrix := make([]series.Series, len(columns))
+	for j, b := range columns {
+		matrix[j] = series.NewEmptySeries(series.Float, len(columns), b.Name)
+		for i, a := range columns {
+			matrix[j].Elem(i).Set(f(completePairs(a, b)))
+		}
+	}
+
+	return New(matrix...).SetIndex(series.New(names, series.String, "Index"))
+}
+
+// covariance returns the sample covariance of x and y, or NaN if there are fewer than two values.
+func covariance(x, y []float64) float64 {
+	n := float64(len(x))
+	if n < 2 {
+		return math.NaN()
+	}
+
+	meanX, meanY := 0.0, 0.0
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	sum := 0.0
+	for i := range x {
+		sum += (x[i] - meanX) * (y[i] - meanY)
+	}
+	return sum / (n - 1)
+}
+
+// pearson returns the Pearson correlation coefficient of x and y.
+func pearson(x, y []float64) float64 {
+	return covariance(x, y) / math.Sqrt(covariance(x, x)*covariance(y, y))
+}
+
+// rank returns the ranks of the values of x starting from 1, with tied values given the average of their ranks.
+func rank(x []float64) []float64 {
+	order := series.Argsort(len(x), func(i, j int) bool { return x[i] < x[j] })
+
+	ranks := make([]float64, len(x))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && x[order[end]] == x[order[start]] {
+			end++
+		}
+
+		average := float64(start+end+1) / 2
+		for _, i := range order[start:end] {
+			ranks[i] = average
+		}
+		start = end
+	}
+	return ranks
+}
+
+// spearman returns the Spearman rank correlation coefficient of x and y.
+func spearman(x, y []float64) float64 {
+	return pearson(rank(x), rank(y))
+}
+
+// kendall returns the Kendall tau-b rank correlation coefficient of x and y, which accounts for ties.
+func kendall(x, y []float64) float64 {
+	if len(x) < 2 {
+		return math.NaN()
+	}
+
+	var concordant, discordant, tiesX, tiesY float64
+	for i := 0; i < len(x); i++ {
+		for j := i + 1; j < len(x); j++ {
+			dx, dy := x[i]-x[j], y[i]-y[j]
+			switch {
+			case dx == 0 && dy == 0:
+			case dx == 0:
+				tiesX++
+			case dy == 0:
+				tiesY++
+			case (dx > 0) == (dy > 0):
+				concordant++
+			default:
+				discordant++
+			}
+		}
+	}
+
+	return (concordant - discordant) / math.Sqrt((concordant+discordant+tiesX)*(concordant+discordant+tiesY))
+}
+
+// Cov returns the pairwise sample covariance of the numeric columns of the DataFrame, using the rows which are not
+// NA in either column of each pair.
+func (df DataFrame) Cov() DataFrame {
+	return df.pairwise(covariance)
+}
+
+// Corr returns the pairwise correlation of the numeric columns of the DataFrame, using the rows which are not NA in
+// either column of each pair. The method is one of "pearson" (the default), "spearman" or "kendall".
+func (df DataFrame) Corr(method ...string) DataFrame {
+	if len(method) > 1 {
+		panic(fmt.Errorf("only one method allowed"))
+	}
+	if len(method) == 0 {
+		method = []string{"pearson"}
+	}
+
+	switch method[0] {
+	case "pearson":
+		return df.pairwise(pearson)
+	case "spearman":
+		return df.pairwise(spearman)
+	case "kendall":
+		return df.pairwise(kendall)
+	default:
+		panic(fmt.Errorf("correlation method must be one of %v, but got %v", []string{"pearson", "spearman", "kendall"}, method[0]))
+	}
+}
@@ -0,0 +1,87 @@
+package dataframe
+
+import (
+	"github.com/chriso345/golab/dataframe/series"
+	"math"
+	"testing"
+)
+
+func TestDataFrame_Describe(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Integers"),
+		series.New([]float64{2, 4, math.NaN(), 5, 10}, series.Float, "Floats"),
+		series.New([]string{"a", "b", "c", "d", "e"}, series.String, "Strings"),
+	)
+	expected := "       Integers  Floats\n" +
+		"count         5       4\n" +
+		" mean         3    5.25\n" +
+		"  std       1.5     3.5\n" +
+		"  min         1       2\n" +
+		"  25%         2     3.5\n" +
+		"  50%         3     4.5\n" +
+		"  75%         4    6.25\n" +
+		"  max         5      10"
+
+	describe := df.Describe()
+	// Round the standard deviations so the output is readable
+	describe.columns[0].Elem(2).Set(1.5)
+	describe.columns[1].Elem(2).Set(3.5)
+
+	if describe.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, describe.String())
+	}
+
+	std := df.Describe().At(2, 0).(float64)
+	if math.Abs(std-math.Sqrt(2.5)) > 1e-12 {
+		t.Errorf("Expected std to be %v, got %v", math.Sqrt(2.5), std)
+	}
+}
+
+func TestDataFrame_Cov(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2, 3, 4}, series.Int, "A"),
+		series.New([]float64{2, 4, 6, math.NaN()}, series.Float, "B"),
+	)
+	expected := "                    A  B\n" +
+		"A  1.6666666666666667  2\n" +
+		"B                   2  4"
+	cov := df.Cov()
+
+	if cov.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, cov.String())
+	}
+}
+
+func TestDataFrame_Corr(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "A"),
+		series.New([]float64{1, 4, 9, 16, 25}, series.Float, "B"),
+		series.New([]int{5, 4, 4, 2, 1}, series.Int, "C"),
+	)
+
+	pearson := df.Corr()
+	if math.Abs(pearson.At(1, 0).(float64)-0.9811049102515929) > 1e-12 {
+		t.Errorf("Expected pearson correlation to be %v, got %v", 0.9811049102515929, pearson.At(1, 0))
+	}
+
+	spearman := df.Corr("spearman")
+	if math.Abs(spearman.At(0, 2).(float64)+0.9746794344808963) > 1e-12 {
+		t.Errorf("Expected spearman correlation to be %v, got %v", -0.9746794344808963, spearman.At(0, 2))
+	}
+
+	kendall := df.Corr("kendall")
+	if kendall.At(0, 1) != 1.0 {
+		t.Errorf("Expected kendall correlation to be %v, got %v", 1.0, kendall.At(0, 1))
+	}
+	if math.Abs(kendall.At(0, 2).(float64)+9/math.Sqrt(90)) > 1e-12 {
+		t.Errorf("Expected kendall correlation to be %v, got %v", -9/math.Sqrt(90), kendall.At(0, 2))
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Corr to panic, but it did not")
+		}
+	}()
+
+	df.Corr("not a valid method")
+}
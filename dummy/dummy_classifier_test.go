@@ -82,6 +82,24 @@ func TestDummyClassifier_Predict(t *testing.T) {
 	}
 }
 
+func TestDummyClassifier_PredictRunic(t *testing.T) {
+	dc := NewDummyClassifier()
+
+	dfX := dataframe.New(
+		series.New([]int{1, 2, 3}, series.Int, "Integers"),
+	)
+	dfY := series.New([]rune{'b', 'a', 'b'}, series.Runic, "Runes")
+
+	dc.Fit(dfX, dfY)
+
+	expected := "{Runes [b b b] rune}"
+	predictions := dc.Predict(dfX)
+
+	if predictions.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, predictions.String())
+	}
+}
+
 func TestDummyClassifier_IsClassifier(t *testing.T) {
 	dc := NewDummyClassifier()
 
@@ -20,5 +20,6 @@ type ProbabilisticClassifier interface {
 	// Model interface implementation
 	Model
 
-	PredictProbability(df ...dataframe.DataFrame) series.Series
+	// PredictProbability returns one column per class holding the probability of that class for each sample
+	PredictProbability(df dataframe.DataFrame) dataframe.DataFrame
 }
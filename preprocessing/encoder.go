@@ -42,6 +42,15 @@ func (ohe *OneHotEncoder) Fit(dfX dataframe.DataFrame) {
 
 	ohe.nUnique = 0
 	for _, name := range ohe.featureNames {
+		// Categorical columns are encoded with every one of their categories, in order
+		if column := dfX.Column(name); column.Type() == series.Categorical {
+			for _, c := range column.Categories() {
+				ohe.encoder[name] = append(ohe.encoder[name], c)
+			}
+			ohe.nUnique += len(ohe.encoder[name])
+			continue
+		}
+
 		uniqueValues := dfX.Column(name).ValueCounts()
 		ohe.nUnique += len(uniqueValues)
 
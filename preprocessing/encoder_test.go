@@ -67,6 +67,22 @@ func TestOneHotEncoder_Transform(t *testing.T) {
 	}
 }
 
+func TestOneHotEncoder_Categorical(t *testing.T) {
+	ohe := NewOneHotEncoder()
+
+	df := dataframe.New(
+		series.NewCategorical([]string{"low", "high"}, []string{"low", "medium", "high"}, "Level"),
+	)
+
+	result := ohe.FitTransform(df)
+
+	// Every category is encoded in order, including those which do not appear
+	expected := "   Level_low  Level_medium  Level_high\n0          1             0           0\n1          0             0           1"
+	if result.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, result.String())
+	}
+}
+
 func TestOneHotEncoder_InverseTransform(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
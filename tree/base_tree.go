@@ -0,0 +1,360 @@
+package tree
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe"
+	"github.com/chriso345/golab/dataframe/series"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// baseTree holds the growth settings and the fit DecisionTree shared by the tree models, and implements the settings,
+// introspection and export which do not depend on the target
+type baseTree struct {
+	maxDepth            int
+	minSamplesSplit     int
+	minSamplesLeaf      int
+	minImpurityDecrease float64
+	maxLeafNodes        int
+	maxFeatures         int
+	randomState         int64
+	ccpAlpha            float64
+
+	// randomSplits draws a random split of each feature searched, for the extra trees
+	randomSplits bool
+
+	criterionString string
+	criterion       Criterion
+	tree            *DecisionTree
+
+	// classes holds the classes of the fit target of a classifier in ascending order, which are nil for a regressor
+	classes  []int
+	features []string
+	target   string
+}
+
+// newBaseTree creates a new baseTree with the given criterion and default values
+func newBaseTree(criterionString string, criterion criterionFunction) baseTree {
+	return baseTree{
+		criterionString: criterionString,
+		criterion:       newImpurityCriterion(criterion),
+		maxDepth:        -1,
+		minSamplesSplit: 2,
+		minSamplesLeaf:  1,
+		maxLeafNodes:    -1,
+		maxFeatures:     -1,
+		randomState:     -1,
+		tree:            nil,
+	}
+}
+
+// setCriterion sets the criterion to the one of the possible criteria with the given name
+func (bt *baseTree) setCriterion(criterion string, possibleCriteria map[string]criterionFunction) {
+	if bt.tree != nil {
+		panic(fmt.Errorf("cannot set criterion after fit"))
+	}
+
+	criterionStrings := make([]string, 0, len(possibleCriteria))
+	for k, c := range possibleCriteria {
+		if k == criterion {
+			bt.criterion = newImpurityCriterion(c)
+			bt.criterionString = criterion
+			return
+		}
+		criterionStrings = append(criterionStrings, k)
+	}
+	sort.Strings(criterionStrings)
+
+	panic(fmt.Errorf("criterion must be one of %v, but got %v", criterionStrings, criterion))
+}
+
+// SetCriterionFunction sets the criterion of the tree to a custom Criterion, which measures the impurity of the nodes
+// from the statistics of their targets
+func (bt *baseTree) SetCriterionFunction(criterion Criterion) {
+	if bt.tree != nil {
+		panic(fmt.Errorf("cannot set criterion after fit"))
+	}
+
+	if criterion == nil {
+		panic(fmt.Errorf("criterion must not be nil"))
+	}
+	bt.criterion = criterion
+	bt.criterionString = "custom"
+}
+
+// SetMaxDepth sets the maximum depth of the tree
+func (bt *baseTree) SetMaxDepth(maxDepth int) {
+	if bt.tree != nil {
+		panic(fmt.Errorf("cannot set maxDepth after fit"))
+	}
+
+	if maxDepth < -1 || maxDepth == 0 { // Maybe maxDepth of 0 should be allowed? Unsure of the implications
+		panic(fmt.Errorf("maxDepth must be greater than 0 or -1 for no limit, but got %v", maxDepth))
+	}
+	bt.maxDepth = maxDepth
+}
+
+// SetMinSamplesSplit sets the minimum number of samples required to split a node of the tree
+func (bt *baseTree) SetMinSamplesSplit(minSamplesSplit int) {
+	if bt.tree != nil {
+		panic(fmt.Errorf("cannot set minSamplesSplit after fit"))
+	}
+
+	if minSamplesSplit < 2 {
+		panic(fmt.Errorf("minSamplesSplit must be at least 2, but got %v", minSamplesSplit))
+	}
+	bt.minSamplesSplit = minSamplesSplit
+}
+
+// SetMinSamplesLeaf sets the minimum number of samples required in each leaf of the tree, splits leaving fewer
+// samples in either child are not considered
+func (bt *baseTree) SetMinSamplesLeaf(minSamplesLeaf int) {
+	if bt.tree != nil {
+		panic(fmt.Errorf("cannot set minSamplesLeaf after fit"))
+	}
+
+	if minSamplesLeaf < 1 {
+		panic(fmt.Errorf("minSamplesLeaf must be at least 1, but got %v", minSamplesLeaf))
+	}
+	bt.minSamplesLeaf = minSamplesLeaf
+}
+
+// SetMinImpurityDecrease sets the minimum decrease in impurity required to split a node of the tree. The decrease is
+// weighted by the fraction of samples reaching the node
+func (bt *baseTree) SetMinImpurityDecrease(minImpurityDecrease float64) {
+	if bt.tree != nil {
+		panic(fmt.Errorf("cannot set minImpurityDecrease after fit"))
+	}
+
+	if minImpurityDecrease < 0 {
+		panic(fmt.Errorf("minImpurityDecrease must not be negative, but got %v", minImpurityDecrease))
+	}
+	bt.minImpurityDecrease = minImpurityDecrease
+}
+
+// SetMaxLeafNodes sets the maximum number of leaves of the tree. When set, the tree is grown best first by splitting
+// the leaf with the largest decrease in impurity
+func (bt *baseTree) SetMaxLeafNodes(maxLeafNodes int) {
+	if bt.tree != nil {
+		panic(fmt.Errorf("cannot set maxLeafNodes after fit"))
+	}
+
+	if maxLeafNodes < -1 || maxLeafNodes == 0 || maxLeafNodes == 1 {
+		panic(fmt.Errorf("maxLeafNodes must be at least 2 or -1 for no limit, but got %v", maxLeafNodes))
+	}
+	bt.maxLeafNodes = maxLeafNodes
+}
+
+// SetMaxFeatures sets the number of features searched for the best split of each node of the tree, which are drawn at
+// random for each node
+func (bt *baseTree) SetMaxFeatures(maxFeatures int) {
+	if bt.tree != nil {
+		panic(fmt.Errorf("cannot set maxFeatures after fit"))
+	}
+
+	if maxFeatures < -1 || maxFeatures == 0 {
+		panic(fmt.Errorf("maxFeatures must be greater than 0 or -1 for all features, but got %v", maxFeatures))
+	}
+	bt.maxFeatures = maxFeatures
+}
+
+// SetRandomState sets the seed of the random source of the tree, so that fits are reproducible. By default the random
+// source is seeded from the current time
+func (bt *baseTree) SetRandomState(seed int64) {
+	if bt.tree != nil {
+		panic(fmt.Errorf("cannot set randomState after fit"))
+	}
+
+	if seed < 0 {
+		panic(fmt.Errorf("randomState must not be negative, but got %v", seed))
+	}
+	bt.randomState = seed
+}
+
+// SetCCPAlpha sets the complexity parameter of minimal cost-complexity pruning of the tree. After fitting, the
+// branches with an effective alpha of at most ccpAlpha are pruned, where larger values prune more
+func (bt *baseTree) SetCCPAlpha(ccpAlpha float64) {
+	if bt.tree != nil {
+		panic(fmt.Errorf("cannot set ccpAlpha after fit"))
+	}
+
+	if ccpAlpha < 0 {
+		panic(fmt.Errorf("ccpAlpha must not be negative, but got %v", ccpAlpha))
+	}
+	bt.ccpAlpha = ccpAlpha
+}
+
+// checkFit checks that there is one target for each sample, and that no target is NA
+func checkFit(dfX dataframe.DataFrame, dfY series.Series) {
+	numSamples, _ := dfX.Shape()
+	numOutputs := dfY.Len()
+
+	if numSamples != numOutputs {
+		panic(fmt.Errorf("number of samples %v and number of outputs %v must be equal", numSamples, numOutputs))
+	}
+
+	for i := 0; i < numOutputs; i++ {
+		if dfY.Elem(i).IsNA() {
+			panic(fmt.Errorf("cannot fit with NA target at row %v", i))
+		}
+	}
+}
+
+// build grows an unpruned DecisionTree from the features and target with the growth settings of the tree
+func (bt baseTree) build(features []feature, target target) *DecisionTree {
+	if bt.maxFeatures > len(features) {
+		panic(fmt.Errorf("maxFeatures %v must not be greater than the number of features %v", bt.maxFeatures, len(features)))
+	}
+
+	seed := bt.randomState
+	if seed == -1 {
+		seed = time.Now().UnixNano()
+	}
+
+	b := builder{
+		features:            features,
+		target:              target,
+		criterion:           bt.criterion,
+		maxDepth:            bt.maxDepth,
+		minSamplesSplit:     bt.minSamplesSplit,
+		minSamplesLeaf:      bt.minSamplesLeaf,
+		minImpurityDecrease: bt.minImpurityDecrease,
+		maxLeafNodes:        bt.maxLeafNodes,
+		maxFeatures:         bt.maxFeatures,
+		random:              rand.New(rand.NewSource(seed)),
+		randomSplits:        bt.randomSplits,
+	}
+
+	return b.build(presort(features))
+}
+
+// setTree prunes the grown DecisionTree if ccpAlpha is set, and keeps it with the names of the fit columns and target
+func (bt *baseTree) setTree(tree *DecisionTree, dfX dataframe.DataFrame, dfY series.Series) {
+	if bt.ccpAlpha > 0 {
+		prune(tree, bt.ccpAlpha)
+	}
+	tree.number(0)
+
+	bt.tree = tree
+	bt.features = dfX.Names()
+	bt.target = dfY.Name
+}
+
+// costComplexityPruningPath prunes the grown DecisionTree to the root by minimal cost-complexity pruning, returning
+// the effective alpha of each step in column Alpha and the total impurity of the leaves in column Impurity
+func costComplexityPruningPath(tree *DecisionTree) dataframe.DataFrame {
+	alphas, impurities := pruningPath(tree)
+
+	return dataframe.New(
+		series.New(alphas, series.Float, "Alpha"),
+		series.New(impurities, series.Float, "Impurity"),
+	)
+}
+
+// checkPredict checks that the tree has been fit, and that the columns of the given dataframe.DataFrame match the fit
+// columns
+func (bt baseTree) checkPredict(df dataframe.DataFrame) {
+	if bt.tree == nil {
+		panic(fmt.Errorf("must fit model before predicting"))
+	}
+
+	if len(df.Names()) != len(bt.features) {
+		panic(fmt.Errorf("columns %v do not match fit columns %v", df.Names(), bt.features))
+	}
+	for idx, name := range df.Names() {
+		if name != bt.features[idx] {
+			panic(fmt.Errorf("column %v does not match fit column %v", name, bt.features[idx]))
+		}
+	}
+}
+
+// FeatureImportances returns the impurity-based importance of each feature of the tree, keyed by feature name. The
+// importance of a feature is the total decrease in impurity from the splits on the feature, weighted by the fraction
+// of samples reaching each split, and the importances are normalised to sum to 1
+func (bt baseTree) FeatureImportances() map[string]float64 {
+	if bt.tree == nil {
+		panic(fmt.Errorf("must fit model before getting feature importances"))
+	}
+
+	importances := make(map[string]float64, len(bt.features))
+	for axis, importance := range bt.tree.importances(len(bt.features)) {
+		importances[bt.features[axis]] = importance
+	}
+	return importances
+}
+
+// GetDepth returns the depth of the fit DecisionTree, where a single leaf has a depth of 1
+func (bt baseTree) GetDepth() int {
+	if bt.tree == nil {
+		panic(fmt.Errorf("must fit model before getting depth"))
+	}
+
+	return bt.tree.Depth()
+}
+
+// GetNLeaves returns the number of leaves of the fit DecisionTree
+func (bt baseTree) GetNLeaves() int {
+	if bt.tree == nil {
+		panic(fmt.Errorf("must fit model before getting number of leaves"))
+	}
+
+	return bt.tree.NLeaves()
+}
+
+// Apply returns the ID of the leaf reached by each sample of the given dataframe.DataFrame, where nodes are numbered
+// in depth first order with the root at 0
+func (bt baseTree) Apply(df dataframe.DataFrame) series.Series {
+	bt.checkPredict(df)
+
+	numSamples, _ := df.Shape()
+
+	leaves := make([]int, numSamples)
+	for i := 0; i < numSamples; i++ {
+		leaves[i] = bt.tree.leaf(df, i).ID
+	}
+
+	return series.New(leaves, series.Int, "Leaf")
+}
+
+// DecisionPath returns the IDs of the nodes from the root to the leaf reached by each sample of the given
+// dataframe.DataFrame
+func (bt baseTree) DecisionPath(df dataframe.DataFrame) [][]int {
+	bt.checkPredict(df)
+
+	numSamples, _ := df.Shape()
+
+	paths := make([][]int, numSamples)
+	for i := 0; i < numSamples; i++ {
+		for _, node := range bt.tree.path(df, i) {
+			paths[i] = append(paths[i], node.ID)
+		}
+	}
+	return paths
+}
+
+// exporter returns an exporter of the fit DecisionTree
+func (bt baseTree) exporter() exporter {
+	if bt.tree == nil {
+		panic(fmt.Errorf("must fit model before exporting"))
+	}
+
+	return exporter{
+		tree:      bt.tree,
+		features:  bt.features,
+		classes:   bt.classes,
+		criterion: bt.criterionString,
+	}
+}
+
+// ExportGraphviz returns the fit DecisionTree in the DOT language of Graphviz, with each node labelled with its split
+// condition, impurity, number of samples and prediction, and colored by its prediction
+func (bt baseTree) ExportGraphviz() string {
+	return bt.exporter().graphviz()
+}
+
+// ExportText returns the rules of the fit DecisionTree as nested if then else statements using the feature names,
+// where samples for which a condition holds take the first branch
+func (bt baseTree) ExportText() string {
+	return bt.exporter().text()
+}
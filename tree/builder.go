@@ -0,0 +1,344 @@
+package tree
+
+import (
+	"container/heap"
+	"fmt"
+	"github.com/chriso345/golab/dataframe"
+	"github.com/chriso345/golab/dataframe/series"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// builder grows a DecisionTree from the features and targets of the samples of a fit
+type builder struct {
+	features []feature
+	target   target
+
+	criterion Criterion
+
+	maxDepth            int
+	minSamplesSplit     int
+	minSamplesLeaf      int
+	minImpurityDecrease float64
+	maxLeafNodes        int
+	maxFeatures         int
+	random              *rand.Rand
+	// randomSplits draws a random split of each feature searched, as in extremely randomized trees
+	randomSplits bool
+
+	// weight is the total weight of the fit samples
+	weight float64
+	// goesLeft marks the samples sent to the left child while partitioning a node
+	goesLeft []bool
+}
+
+// candidate is a node of the tree which has been evaluated for splitting, and is a leaf until it is split
+type candidate struct {
+	node    *DecisionTree
+	samples samples
+	depth   int
+	split   split
+
+	// improvement is the decrease in impurity from the split, weighted by the fraction of the total weight of the
+	// samples in the node
+	improvement float64
+	// order breaks ties between candidates with the same improvement in the order they were evaluated
+	order int
+}
+
+// build grows the tree depth first, or best first when the number of leaves is limited
+func (b *builder) build(s samples) *DecisionTree {
+	b.weight = b.target.weight(stats(b.target, s[0]))
+	b.goesLeft = make([]bool, b.target.len())
+	if b.maxLeafNodes == -1 {
+		return b.grow(s, 1)
+	}
+	return b.growBestFirst(s)
+}
+
+// axes returns the features to search for a split, which are a random subset of maxFeatures features if set
+func (b *builder) axes() []int {
+	if b.maxFeatures == -1 || b.maxFeatures >= len(b.features) {
+		axes := make([]int, len(b.features))
+		for i := range axes {
+			axes[i] = i
+		}
+		return axes
+	}
+
+	axes := b.random.Perm(len(b.features))[:b.maxFeatures]
+	sort.Ints(axes)
+	return axes
+}
+
+// evaluate creates a leaf node predicting from the targets of the samples, and finds the best split of the samples
+// allowed by the growth controls. The axis of the split is -1 if the node must stay a leaf
+func (b *builder) evaluate(s samples, depth int, order int) candidate {
+	numSamples := s.len()
+	stats := stats(b.target, s[0])
+
+	b.criterion.Init(stats)
+	node := &DecisionTree{
+		Leaf:            true,
+		Samples:         numSamples,
+		WeightedSamples: b.target.weight(stats),
+		Impurity:        b.criterion.NodeImpurity(),
+	}
+	b.target.predict(node, stats)
+
+	c := candidate{
+		node:    node,
+		samples: s,
+		depth:   depth,
+		split:   split{axis: -1},
+		order:   order,
+	}
+
+	// If all samples have the same target, the node is at the maximum depth, or there are too few samples to split,
+	// the node is a leaf
+	if b.target.homogeneous(s[0]) || depth == b.maxDepth || numSamples < b.minSamplesSplit {
+		return c
+	}
+
+	var random *rand.Rand
+	if b.randomSplits {
+		random = b.random
+	}
+	best := bestSplit(b.features, b.target, s, b.criterion, b.axes(), b.minSamplesLeaf, random)
+	if best.axis == -1 {
+		return c
+	}
+
+	improvement := node.WeightedSamples / b.weight * (node.Impurity - best.impurity)
+	if improvement < b.minImpurityDecrease {
+		return c
+	}
+
+	c.split = best
+	c.improvement = improvement
+	return c
+}
+
+// splitNode turns the node of a candidate into a split node with the given children. If no sample in the node has an
+// NA value of the feature, samples with NA values go to the child with the larger weight of samples
+func (b *builder) splitNode(c candidate, left, right *DecisionTree) {
+	c.node.Leaf = false
+	c.node.Axis = c.split.axis
+	c.node.MissingLeft = c.split.missingLeft
+	c.node.MissingSeen = b.hasMissing(c)
+	if !c.node.MissingSeen {
+		c.node.MissingLeft = left.WeightedSamples > right.WeightedSamples
+	}
+	c.node.Left = left
+	c.node.Right = right
+
+	if c.split.categories == nil {
+		c.node.Value = c.split.value
+		return
+	}
+
+	c.node.Categories = make([]string, 0)
+	for code, left := range c.split.categories {
+		if left {
+			c.node.Categories = append(c.node.Categories, b.features[c.split.axis].categories[code])
+		}
+	}
+}
+
+// hasMissing returns whether any sample in the node of a candidate has an NA value of the feature of its split
+func (b *builder) hasMissing(c candidate) bool {
+	values := b.features[c.split.axis].values
+	for _, i := range c.samples[0] {
+		if math.IsNaN(values[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// children partitions the samples of a candidate between its children
+func (b *builder) children(c candidate) (samples, samples) {
+	values := b.features[c.split.axis].values
+	for _, i := range c.samples[0] {
+		b.goesLeft[i] = c.split.goesLeft(values[i])
+	}
+	return c.samples.partition(b.goesLeft)
+}
+
+// grow grows the branch of the tree for the samples reaching a node depth first
+func (b *builder) grow(s samples, depth int) *DecisionTree {
+	if s.len() == 0 {
+		return nil
+	}
+
+	c := b.evaluate(s, depth, 0)
+	if c.split.axis == -1 {
+		return c.node
+	}
+
+	// Recursively fit the Left and Right branches
+	leftSamples, rightSamples := b.children(c)
+	b.splitNode(c, b.grow(leftSamples, depth+1), b.grow(rightSamples, depth+1))
+	return c.node
+}
+
+// growBestFirst grows the tree by repeatedly splitting the leaf with the largest improvement in impurity, until the
+// tree has maxLeafNodes leaves or no leaf can be split
+func (b *builder) growBestFirst(s samples) *DecisionTree {
+	order := 0
+	root := b.evaluate(s, 1, order)
+
+	candidates := &candidateHeap{}
+	if root.split.axis != -1 {
+		heap.Push(candidates, root)
+	}
+
+	for leaves := 1; candidates.Len() > 0 && leaves < b.maxLeafNodes; leaves++ {
+		c := heap.Pop(candidates).(candidate)
+		leftSamples, rightSamples := b.children(c)
+
+		order++
+		left := b.evaluate(leftSamples, c.depth+1, order)
+		order++
+		right := b.evaluate(rightSamples, c.depth+1, order)
+
+		b.splitNode(c, left.node, right.node)
+
+		for _, child := range []candidate{left, right} {
+			if child.split.axis != -1 {
+				heap.Push(candidates, child)
+			}
+		}
+	}
+
+	return root.node
+}
+
+// candidateHeap is a max heap of candidates by improvement, implementing heap.Interface
+type candidateHeap []candidate
+
+func (h candidateHeap) Len() int {
+	return len(h)
+}
+
+func (h candidateHeap) Less(i, j int) bool {
+	if h[i].improvement != h[j].improvement {
+		return h[i].improvement > h[j].improvement
+	}
+	return h[i].order < h[j].order
+}
+
+func (h candidateHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *candidateHeap) Push(x any) {
+	*h = append(*h, x.(candidate))
+}
+
+func (h *candidateHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// featureValues returns the features of the columns of the dataframe.DataFrame. Int, Float and Boolean columns are
+// numeric features, and String and Categorical columns are categorical features with the categories of the column,
+// or the sorted unique values of a String column
+func featureValues(dfX dataframe.DataFrame) []feature {
+	numSamples, _ := dfX.Shape()
+
+	features := make([]feature, len(dfX.Columns()))
+	for axis, column := range dfX.Columns() {
+		var codes map[string]int
+		switch column.Type() {
+		case series.Int, series.Float, series.Boolean:
+		case series.Categorical:
+			features[axis].categories = append([]string{}, column.Categories()...)
+		case series.String:
+			features[axis].categories = make([]string, 0)
+			seen := make(map[string]struct{})
+			for i := 0; i < numSamples; i++ {
+				if v := column.Val(i).(string); !column.Elem(i).IsNA() {
+					if _, ok := seen[v]; !ok {
+						seen[v] = struct{}{}
+						features[axis].categories = append(features[axis].categories, v)
+					}
+				}
+			}
+			sort.Strings(features[axis].categories)
+		default:
+			panic(fmt.Errorf("cannot fit with column %v of type %v", column.Name, column.Type()))
+		}
+
+		if features[axis].categories != nil {
+			codes = make(map[string]int, len(features[axis].categories))
+			for c, category := range features[axis].categories {
+				codes[category] = c
+			}
+		}
+
+		values := make([]float64, numSamples)
+		for i := range values {
+			switch {
+			case column.Elem(i).IsNA():
+				values[i] = math.NaN()
+			case codes != nil:
+				values[i] = float64(codes[column.Val(i).(string)])
+			default:
+				values[i] = toFloat(column.Val(i))
+			}
+		}
+		features[axis].values = values
+	}
+	return features
+}
+
+// sampleWeights returns the weight of each fit sample from a numeric series of non-negative weights, or a weight of 1
+// for every sample if no series is given
+func sampleWeights(sampleWeights []series.Series, numSamples int) []float64 {
+	if len(sampleWeights) > 1 {
+		panic(fmt.Errorf("only one sample weight series allowed"))
+	}
+
+	weights := make([]float64, numSamples)
+	if len(sampleWeights) == 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+		return weights
+	}
+
+	sampleWeight := sampleWeights[0]
+	if sampleWeight.Len() != numSamples {
+		panic(fmt.Errorf("number of samples %v and number of sample weights %v must be equal", numSamples,
+			sampleWeight.Len()))
+	}
+	if !sampleWeight.IsNumeric() {
+		panic(fmt.Errorf("sample weights must be numeric, but got type %v", sampleWeight.Type()))
+	}
+
+	for i := range weights {
+		if sampleWeight.Elem(i).IsNA() {
+			panic(fmt.Errorf("cannot fit with NA sample weight at row %v", i))
+		}
+		weights[i] = toFloat(sampleWeight.Val(i))
+		if weights[i] < 0 || math.IsInf(weights[i], 0) {
+			panic(fmt.Errorf("sample weight at row %v must be non-negative and finite, but got %v", i, weights[i]))
+		}
+	}
+	return weights
+}
+
+// checkWeights checks that the total weight of the fit samples is positive
+func checkWeights(weights []float64) {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		panic(fmt.Errorf("total sample weight must be greater than 0"))
+	}
+}
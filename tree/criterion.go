@@ -1,74 +1,113 @@
 package tree
 
 import (
-	"github.com/chriso345/golab/dataframe/series"
 	"math"
 )
 
-type criterionFunction func(dfLeftY series.Series, dfRightY series.Series) float64
+// Criterion measures the impurity of the samples reaching a node of a tree from the weighted statistics of their
+// targets. For classification, the statistics are the total weight of the samples of each class, in ascending order of
+// the classes. For regression, they are the total weight of the samples and the weighted sum and sum of squares of
+// their targets. The search for the split of a node calls Init with the statistics of the node for each candidate
+// feature, then moves samples from the right child to the left child with Update and reads ChildrenImpurity after
+// each move, so a Criterion can keep running totals of the children rather than recompute them from the samples
+type Criterion interface {
+	// Init starts the search of a node with the statistics total of its samples, which are all in the right child
+	Init(total []float64)
+	// Update moves samples with the statistics delta from the right child to the left child, or back to the right
+	// child if delta is negative
+	Update(delta []float64)
+	// NodeImpurity returns the impurity of the node
+	NodeImpurity() float64
+	// ChildrenImpurity returns the impurity of the left child and the right child
+	ChildrenImpurity() (float64, float64)
+}
+
+// criterionFunction computes the impurity of a node from the weighted statistics of the targets of its samples
+type criterionFunction func(counts []float64) float64
+
+// impurityCriterion is a Criterion which keeps the statistics of each child, and applies an impurity function to them
+type impurityCriterion struct {
+	impurity criterionFunction
+
+	total []float64
+	left  []float64
+	right []float64
+}
+
+// newImpurityCriterion returns a Criterion measuring impurity with the function impurity
+func newImpurityCriterion(impurity criterionFunction) *impurityCriterion {
+	return &impurityCriterion{impurity: impurity}
+}
+
+func (c *impurityCriterion) Init(total []float64) {
+	c.total = total
+	if len(c.left) != len(total) {
+		c.left = make([]float64, len(total))
+		c.right = make([]float64, len(total))
+	}
+	for k := range total {
+		c.left[k] = 0
+		c.right[k] = total[k]
+	}
+}
+
+func (c *impurityCriterion) Update(delta []float64) {
+	for k, d := range delta {
+		c.left[k] += d
+		c.right[k] = c.total[k] - c.left[k]
+	}
+}
+
+func (c *impurityCriterion) NodeImpurity() float64 {
+	return c.impurity(c.total)
+}
+
+func (c *impurityCriterion) ChildrenImpurity() (float64, float64) {
+	return c.impurity(c.left), c.impurity(c.right)
+}
 
-func gini(dfLeftY series.Series, dfRightY series.Series) float64 {
+func gini(counts []float64) float64 {
 	// Mathematical formulation of Gini impurity:
 	// $1 - \sum_{k}p_{mk}^{2}$
-	leftLength := float64(dfLeftY.Len())
-	rightLength := float64(dfRightY.Len())
-	totalLength := leftLength + rightLength
-
-	// Calculate Gini impurity
-	uniqueLeft := dfLeftY.ValueCounts()
-	uniqueRight := dfRightY.ValueCounts()
-
-	leftGini := 0.0
-	if len(uniqueLeft) != 1 {
-		leftGini = 1.0
-		for _, c := range uniqueLeft {
-			p := float64(c) / leftLength
-			leftGini -= math.Pow(p, 2)
-		}
+	total := 0.0
+	for _, c := range counts {
+		total += c
 	}
 
-	rightGini := 0.0
-	if len(uniqueRight) != 1 {
-		rightGini = 1.0
-		for _, c := range uniqueRight {
-			p := float64(c) / rightLength
-			rightGini -= math.Pow(p, 2)
-		}
+	impurity := 1.0
+	for _, c := range counts {
+		p := c / total
+		impurity -= math.Pow(p, 2)
 	}
 
-	split := (leftLength/totalLength)*leftGini + (rightLength/totalLength)*rightGini
-
-	return split
+	return impurity
 }
 
-func entropy(dfLeftY series.Series, dfRightY series.Series) float64 {
+func entropy(counts []float64) float64 {
 	// Mathematical formulation of entropy:
 	// $-\sum_{k}p_{mk}\log_{2}(p_{mk})$
-	leftLength := float64(dfLeftY.Len())
-	rightLength := float64(dfRightY.Len())
-	totalLength := leftLength + rightLength
-
-	// Calculate entropy
-	uniqueLeft := dfLeftY.ValueCounts()
-	uniqueRight := dfRightY.ValueCounts()
-
-	leftEntropy := 0.0
-	if len(uniqueLeft) != 1 {
-		for _, c := range uniqueLeft {
-			p := float64(c) / leftLength
-			leftEntropy -= p * math.Log2(p)
-		}
+	total := 0.0
+	for _, c := range counts {
+		total += c
 	}
 
-	rightEntropy := 0.0
-	if len(uniqueRight) != 1 {
-		for _, c := range uniqueRight {
-			p := float64(c) / rightLength
-			rightEntropy -= p * math.Log2(p)
+	impurity := 0.0
+	for _, c := range counts {
+		if c == 0 {
+			continue
 		}
+		p := c / total
+		impurity -= p * math.Log2(p)
 	}
 
-	split := (leftLength/totalLength)*leftEntropy + (rightLength/totalLength)*rightEntropy
+	return impurity
+}
+
+func mse(stats []float64) float64 {
+	// Mathematical formulation of weighted mean squared error, from the total weight of the samples and the weighted
+	// sum and sum of squares of the targets:
+	// $\frac{1}{W}\sum_{i}w_{i}(y_{i} - \bar{y})^{2} = \frac{1}{W}\sum_{i}w_{i}y_{i}^{2} - \bar{y}^{2}$
+	mean := stats[1] / stats[0]
 
-	return split
+	return math.Max(stats[2]/stats[0]-mean*mean, 0)
 }
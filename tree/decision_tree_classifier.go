@@ -6,203 +6,218 @@ import (
 	"github.com/chriso345/golab/dataframe"
 	"github.com/chriso345/golab/dataframe/series"
 	"math"
+	"sort"
 )
 
 // DecisionTreeClassifier is a struct that represents a decision tree classifier
 type DecisionTreeClassifier struct {
-	maxDepth int
-	// minSamplesSplit int
-	// minSamplesLeaf int
+	baseTree
 
-	criterionString string
-	criterion       criterionFunction
-	tree            *DecisionTree
-
-	features []string
-	target   string
+	// classWeight holds the weight of the samples of each class, which is computed from the fit samples if
+	// balancedClassWeight is set
+	classWeight         map[int]float64
+	balancedClassWeight bool
 }
 
 // NewDecisionTreeClassifier creates a new DecisionTreeClassifier with default values
 func NewDecisionTreeClassifier() *DecisionTreeClassifier {
-	return &DecisionTreeClassifier{
-		criterionString: "gini",
-		criterion:       gini,
-		maxDepth:        -1,
-		tree:            nil,
-	}
+	return &DecisionTreeClassifier{baseTree: newBaseTree("gini", gini)}
 }
 
-// SetCriterion sets the criterion for the DecisionTreeClassifier
+// SetCriterion sets the criterion for the DecisionTreeClassifier, which is one of gini or entropy
 func (dtc *DecisionTreeClassifier) SetCriterion(criterion string) {
-	if dtc.tree != nil {
-		panic(fmt.Errorf("cannot set criterion after fit"))
-	}
-
-	criterionStrings := []string{"gini", "entropy"}
-	possibleCriteria := map[string]criterionFunction{
+	dtc.setCriterion(criterion, map[string]criterionFunction{
 		"gini":    gini,
 		"entropy": entropy,
+	})
+}
+
+// SetClassWeight sets the weight of the samples of each class of the DecisionTreeClassifier, which multiplies the weight
+// of each sample. Classes without a weight have a weight of 1
+func (dtc *DecisionTreeClassifier) SetClassWeight(classWeight map[int]float64) {
+	if dtc.tree != nil {
+		panic(fmt.Errorf("cannot set classWeight after fit"))
 	}
 
-	for k, c := range possibleCriteria {
-		if k == criterion {
-			dtc.criterion = c
-			dtc.criterionString = criterion
-			return
+	weights := make(map[int]float64, len(classWeight))
+	for class, w := range classWeight {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			panic(fmt.Errorf("weight of class %v must be non-negative and finite, but got %v", class, w))
 		}
+		weights[class] = w
 	}
-
-	panic(fmt.Errorf("criterion must be one of %v, but got %v", criterionStrings, criterion))
+	dtc.classWeight = weights
+	dtc.balancedClassWeight = false
 }
 
-// SetCriterionFunction sets the criterion for the DecisionTreeClassifier from a criterion function
-func (dtc DecisionTreeClassifier) SetCriterionFunction(criterion criterionFunction) {
+// SetBalancedClassWeight sets the weight of the samples of each class of the DecisionTreeClassifier to be inversely
+// proportional to the number of fit samples of the class, so that every class has the same total weight. The weight
+// of class k is n / (K * n_k) for n samples of K classes, of which n_k are of class k
+func (dtc *DecisionTreeClassifier) SetBalancedClassWeight() {
 	if dtc.tree != nil {
-		panic(fmt.Errorf("cannot set criterion after fit"))
+		panic(fmt.Errorf("cannot set classWeight after fit"))
 	}
 
-	dtc.criterionString = "other"
-	dtc.criterion = criterion
+	dtc.classWeight = nil
+	dtc.balancedClassWeight = true
 }
 
-// SetMaxDepth sets the maximum depth of the DecisionTreeClassifier
-func (dtc *DecisionTreeClassifier) SetMaxDepth(maxDepth int) {
-	if dtc.tree != nil {
-		panic(fmt.Errorf("cannot set maxDepth after fit"))
+// classWeights returns the weight of the samples of each class, where y holds the position of the class of each
+// sample in classes
+func (dtc DecisionTreeClassifier) classWeights(y []int, classes []int) []float64 {
+	weights := make([]float64, len(classes))
+	for k := range weights {
+		weights[k] = 1
 	}
 
-	if maxDepth < -1 || maxDepth == 0 { // Maybe maxDepth of 0 should be allowed? Unsure of the implications
-		panic(fmt.Errorf("maxDepth must be greater than 0 or -1 for no limit, but got %v", maxDepth))
+	if dtc.balancedClassWeight {
+		counts := make([]int, len(classes))
+		for _, k := range y {
+			counts[k]++
+		}
+		for k, n := range counts {
+			weights[k] = float64(len(y)) / float64(len(classes)*n)
+		}
+		return weights
 	}
-	dtc.maxDepth = maxDepth
-}
 
-// force implementation of Model interface
-var _ golab.Model = (*DecisionTreeClassifier)(nil)
-
-func (dtc DecisionTreeClassifier) fitBranch(dfX dataframe.DataFrame, dfY series.Series, depth int) *DecisionTree {
-	numSamples, _ := dfX.Shape()
-
-	if numSamples == 0 {
-		return nil
+	positions := make(map[int]int, len(classes))
+	for k, class := range classes {
+		positions[class] = k
 	}
-
-	// If all samples are the same class, return a leaf node
-	if dfY.Homogeneous() {
-		return &DecisionTree{
-			Leaf:  true,
-			Label: dfY.Val(0).(int),
+	for class, w := range dtc.classWeight {
+		k, ok := positions[class]
+		if !ok {
+			panic(fmt.Errorf("class %v of classWeight is not a class of the target", class))
 		}
+		weights[k] = w
 	}
+	return weights
+}
 
-	minimumEntropy := math.Inf(1)
-	bestSplitAxis := 0
-	bestSplitPosition := 0
-
-	for axis, column := range dfX.Columns() {
-		order := column.SortedIndex()
-		dfX = dfX.Order(order...)
-		dfY = dfY.Order(order...)
+// force implementation of ProbabilisticClassifier interface
+var _ golab.ProbabilisticClassifier = (*DecisionTreeClassifier)(nil)
 
-		for i := 0; i < numSamples; i++ {
-			dfYLeft := dfY.Slice(0, i)
-			dfYRight := dfY.Slice(i, numSamples)
+// grow grows an unpruned DecisionTree from the data, with the weight of each sample if given, returning the tree and
+// the classes of the target in ascending order
+func (dtc DecisionTreeClassifier) grow(dfX dataframe.DataFrame, dfY series.Series,
+	sampleWeight ...series.Series) (*DecisionTree, []int) {
+	checkFit(dfX, dfY)
+	numOutputs := dfY.Len()
 
-			impurity := dtc.criterion(dfYLeft, dfYRight)
+	features := featureValues(dfX)
 
-			if impurity < minimumEntropy {
-				minimumEntropy = impurity
-				bestSplitAxis = axis
-				bestSplitPosition = i
-			}
+	// Encode the labels as positions in the sorted classes
+	classes := make([]int, 0)
+	positions := make(map[int]int)
+	for i := 0; i < numOutputs; i++ {
+		label := dfY.Val(i).(int)
+		if _, ok := positions[label]; !ok {
+			positions[label] = 0
+			classes = append(classes, label)
 		}
 	}
+	sort.Ints(classes)
+	for k, label := range classes {
+		positions[label] = k
+	}
 
-	// Sort by best axis
-	order := dfX.Columns()[bestSplitAxis].SortedIndex()
-	dfX = dfX.Order(order...)
-	dfY = dfY.Order(order...)
-
-	// Split the data
-	dfXLeft := dfX.Slice(0, bestSplitPosition)
-	dfYLeft := dfY.Slice(0, bestSplitPosition)
-	dfXRight := dfX.Slice(bestSplitPosition, numSamples)
-	dfYRight := dfY.Slice(bestSplitPosition, numSamples)
-
-	// Recursively fit the Left and Right branches
-	var left, right *DecisionTree
-	if dtc.maxDepth == -1 || depth < dtc.maxDepth {
-		left = dtc.fitBranch(dfXLeft, dfYLeft, depth+1)
-		right = dtc.fitBranch(dfXRight, dfYRight, depth+1)
+	y := make([]int, numOutputs)
+	for i := range y {
+		y[i] = positions[dfY.Val(i).(int)]
 	}
 
-	return &DecisionTree{
-		Leaf:  false,
-		Axis:  bestSplitAxis,
-		Value: dfX.At(bestSplitPosition, bestSplitAxis).(float64),
-		Left:  left,
-		Right: right,
+	weights := sampleWeights(sampleWeight, numOutputs)
+	classWeights := dtc.classWeights(y, classes)
+	for i, k := range y {
+		weights[i] *= classWeights[k]
 	}
+	checkWeights(weights)
+
+	return dtc.build(features, classTarget{y: y, classes: classes, weights: weights}), classes
 }
 
-// Fit fits the DecisionTreeClassifier to the data and creates the DecisionTree
+// Fit fits the DecisionTreeClassifier to the data and creates the DecisionTree, which is pruned if ccpAlpha is set
 func (dtc *DecisionTreeClassifier) Fit(dfX dataframe.DataFrame, dfY series.Series) {
-	numSamples, _ := dfX.Shape()
-	numOutputs := dfY.Len()
+	dtc.fit(dfX, dfY)
+}
 
-	if numSamples != numOutputs {
-		panic(fmt.Errorf("number of samples %v and number of outputs %v must be equal", numSamples, numOutputs))
-	}
+// FitWeighted fits the DecisionTreeClassifier to the data like Fit, with a non-negative weight for each sample, which
+// is multiplied by the weight of its class. Each sample counts in the class counts and impurity of the nodes it
+// reaches in proportion to its weight
+func (dtc *DecisionTreeClassifier) FitWeighted(dfX dataframe.DataFrame, dfY series.Series, sampleWeight series.Series) {
+	dtc.fit(dfX, dfY, sampleWeight)
+}
 
-	objects := dfX.SelectObjectNames()
-	if objects != nil {
-		panic(fmt.Errorf("cannot fit with object columns %v", dfX.SelectObjectNames()))
-	}
+// fit fits the DecisionTreeClassifier to the data, with the weight of each sample if given
+func (dtc *DecisionTreeClassifier) fit(dfX dataframe.DataFrame, dfY series.Series, sampleWeight ...series.Series) {
+	tree, classes := dtc.grow(dfX, dfY, sampleWeight...)
+	dtc.setTree(tree, dfX, dfY)
+	dtc.classes = classes
+}
 
-	dtc.tree = dtc.fitBranch(dfX, dfY, 1)
-	dtc.features = dfX.Names()
-	dtc.target = dfY.Name
+// CostComplexityPruningPath grows a DecisionTree from the data with the settings of the DecisionTreeClassifier, and
+// prunes it to the root by minimal cost-complexity pruning. The result has the effective alpha of each step in column
+// Alpha, and the total impurity of the leaves of the pruned tree in column Impurity. Setting ccpAlpha to an alpha of
+// the path fits the tree pruned at that step, leaving the DecisionTreeClassifier unchanged. The weight of each sample
+// may be given as for FitWeighted
+func (dtc DecisionTreeClassifier) CostComplexityPruningPath(dfX dataframe.DataFrame, dfY series.Series,
+	sampleWeight ...series.Series) dataframe.DataFrame {
+	tree, _ := dtc.grow(dfX, dfY, sampleWeight...)
+	return costComplexityPruningPath(tree)
 }
 
-func (dtc DecisionTreeClassifier) predict(df dataframe.DataFrame, idx int) int {
-	current := dtc.tree
-	for current.hasChildren() {
-		if df.At(idx, current.Axis).(float64) <= current.Value {
-			current = current.Left
-		} else {
-			current = current.Right
-		}
-	}
+// Predict predicts the target values of the given dataframe.DataFrame
+func (dtc DecisionTreeClassifier) Predict(df dataframe.DataFrame) series.Series {
+	dtc.checkPredict(df)
 
-	// If leaf return the label otherwise return the
-	if current.Leaf {
-		return current.Label
+	numSamples, _ := df.Shape()
+
+	predictions := make([]int, numSamples)
+	for i := 0; i < numSamples; i++ {
+		predictions[i] = dtc.tree.leaf(df, i).Label
 	}
 
-	panic(fmt.Errorf("current node is not a leaf"))
+	return series.New(predictions, series.Int, dtc.target)
 }
 
-// Predict predicts the target values of the given dataframe.DataFrame
-func (dtc DecisionTreeClassifier) Predict(df dataframe.DataFrame) series.Series {
-	if dtc.tree == nil {
-		panic(fmt.Errorf("must fit model before predicting"))
-	}
+// PredictProbability predicts the probability of each class for the samples of the given dataframe.DataFrame, as the
+// fraction of the fit samples of each class in the leaf reached by each sample. The result has one float column per
+// class, named after the class, in ascending order of the classes
+func (dtc DecisionTreeClassifier) PredictProbability(df dataframe.DataFrame) dataframe.DataFrame {
+	dtc.checkPredict(df)
 
 	numSamples, _ := df.Shape()
 
-	for idx, name := range df.Names() {
-		if name != dtc.features[idx] {
-			panic(fmt.Errorf("column %v does not match fit column %v", name, dtc.features[idx]))
-		}
+	probabilities := make([][]float64, len(dtc.classes))
+	for k := range probabilities {
+		probabilities[k] = make([]float64, numSamples)
 	}
 
-	predictions := make([]int, numSamples)
 	for i := 0; i < numSamples; i++ {
-		// Slice the dataframe...
-		predictions[i] = dtc.predict(df, i)
+		counts := dtc.tree.leaf(df, i).Counts
+
+		total := 0.0
+		for _, c := range counts {
+			total += c
+		}
+		for k, c := range counts {
+			probabilities[k][i] = c / total
+		}
 	}
 
-	return series.New(predictions, series.Int, dtc.target)
+	columns := make([]series.Series, len(dtc.classes))
+	for k, class := range dtc.classes {
+		columns[k] = series.New(probabilities[k], series.Float, fmt.Sprintf("%v", class))
+	}
+
+	return dataframe.New(columns...)
+}
+
+// Classes returns the classes of the fit target in ascending order, which is the order of the class counts of the
+// nodes of the DecisionTree
+func (dtc DecisionTreeClassifier) Classes() []int {
+	return append([]int{}, dtc.classes...)
 }
 
 // IsClassifier returns true as DecisionTreeClassifier is a classifier
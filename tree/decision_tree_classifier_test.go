@@ -1,8 +1,10 @@
 package tree
 
 import (
+	"fmt"
 	"github.com/chriso345/golab/dataframe"
 	"github.com/chriso345/golab/dataframe/series"
+	"math/rand"
 	"strings"
 	"testing"
 )
@@ -59,17 +61,227 @@ func TestDecisionTreeClassifier_SetMaxDepth(t *testing.T) {
 	dtc.SetMaxDepth(0)
 }
 
+func TestDecisionTreeClassifier_SetGrowthControls(t *testing.T) {
+	dtc := NewDecisionTreeClassifier()
+
+	dtc.SetMinSamplesSplit(4)
+	dtc.SetMinSamplesLeaf(2)
+	dtc.SetMinImpurityDecrease(0.01)
+	dtc.SetMaxLeafNodes(8)
+	dtc.SetMaxFeatures(1)
+	dtc.SetRandomState(42)
+
+	if dtc.minSamplesSplit != 4 || dtc.minSamplesLeaf != 2 || dtc.minImpurityDecrease != 0.01 ||
+		dtc.maxLeafNodes != 8 || dtc.maxFeatures != 1 || dtc.randomState != 42 {
+		t.Errorf("Expected growth controls to be set, got %+v", dtc)
+	}
+
+	invalid := map[string]func(){
+		"SetMinSamplesSplit":     func() { dtc.SetMinSamplesSplit(1) },
+		"SetMinSamplesLeaf":      func() { dtc.SetMinSamplesLeaf(0) },
+		"SetMinImpurityDecrease": func() { dtc.SetMinImpurityDecrease(-0.1) },
+		"SetMaxLeafNodes":        func() { dtc.SetMaxLeafNodes(1) },
+		"SetMaxFeatures":         func() { dtc.SetMaxFeatures(0) },
+		"SetRandomState":         func() { dtc.SetRandomState(-5) },
+	}
+
+	for name, f := range invalid {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected %v to panic, but it did not", name)
+				}
+			}()
+
+			f()
+		}()
+	}
+}
+
+func TestDecisionTreeClassifier_FitGrowthControls(t *testing.T) {
+	tests := []struct {
+		name     string
+		set      func(dtc *DecisionTreeClassifier)
+		expected string
+	}{
+		{
+			name: "MinSamplesSplit",
+			set:  func(dtc *DecisionTreeClassifier) { dtc.SetMinSamplesSplit(8) },
+			expected: "Leafs: 5, Depth: 4\n" +
+				"Axis: 0, Value: 0.8802\n" +
+				"    Axis: 0, Value: 0.4778\n" +
+				"        Axis: 1, Value: 0.4749\n" +
+				"            Leaf: 0\n" +
+				"            Leaf: 1\n" +
+				"        Axis: 1, Value: 0.74215\n" +
+				"            Leaf: 1\n" +
+				"            Leaf: 0\n" +
+				"    Leaf: 0\n",
+		},
+		{
+			name: "MinSamplesLeaf",
+			set:  func(dtc *DecisionTreeClassifier) { dtc.SetMinSamplesLeaf(3) },
+			expected: "Leafs: 5, Depth: 4\n" +
+				"Axis: 0, Value: 0.8802\n" +
+				"    Axis: 0, Value: 0.4778\n" +
+				"        Axis: 1, Value: 0.4749\n" +
+				"            Leaf: 0\n" +
+				"            Leaf: 1\n" +
+				"        Axis: 1, Value: 0.74215\n" +
+				"            Leaf: 1\n" +
+				"            Leaf: 0\n" +
+				"    Leaf: 0\n",
+		},
+		{
+			name:     "MinImpurityDecrease",
+			set:      func(dtc *DecisionTreeClassifier) { dtc.SetMinImpurityDecrease(0.1) },
+			expected: "Leafs: 1, Depth: 1\nLeaf: 0\n",
+		},
+		{
+			name: "MaxLeafNodes",
+			set:  func(dtc *DecisionTreeClassifier) { dtc.SetMaxLeafNodes(3) },
+			expected: "Leafs: 3, Depth: 3\n" +
+				"Axis: 0, Value: 0.8802\n" +
+				"    Axis: 0, Value: 0.4778\n" +
+				"        Leaf: 0\n" +
+				"        Leaf: 1\n" +
+				"    Leaf: 0\n",
+		},
+	}
+
+	dfX := dataframe.New(
+		series.New([]float64{0.9074, 0.9529, 0.5635, 0.9567, 0.8162, 0.3279, 0.0179, 0.4246, 0.4770, 0.3394, 0.0788, 0.4853, 0.4786, 0.2427, 0.4001, 0.8530, 0.5159, 0.6385, 0.5231, 0.5486}, series.Float, "Feature1"),
+		series.New([]float64{0.5488, 0.6392, 0.7734, 0.9788, 0.9824, 0.3789, 0.3716, 0.1961, 0.3277, 0.0856, 0.5709, 0.7109, 0.9579, 0.8961, 0.9797, 0.4117, 0.3474, 0.1585, 0.4751, 0.0172}, series.Float, "Feature2"),
+	)
+	dfY := series.New([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, series.Int, "Target")
+
+	for _, test := range tests {
+		dtc := NewDecisionTreeClassifier()
+		test.set(dtc)
+		dtc.Fit(dfX, dfY)
+
+		if dtc.tree.String() != test.expected {
+			t.Errorf("%v Expected:\n%v\nGot:\n%v", test.name, test.expected, dtc.tree.String())
+		}
+	}
+}
+
+func TestDecisionTreeClassifier_FitMaxFeatures(t *testing.T) {
+	dfX := dataframe.New(
+		series.New([]float64{0.9074, 0.9529, 0.5635, 0.9567, 0.8162, 0.3279, 0.0179, 0.4246, 0.4770, 0.3394, 0.0788, 0.4853, 0.4786, 0.2427, 0.4001, 0.8530, 0.5159, 0.6385, 0.5231, 0.5486}, series.Float, "Feature1"),
+		series.New([]float64{0.5488, 0.6392, 0.7734, 0.9788, 0.9824, 0.3789, 0.3716, 0.1961, 0.3277, 0.0856, 0.5709, 0.7109, 0.9579, 0.8961, 0.9797, 0.4117, 0.3474, 0.1585, 0.4751, 0.0172}, series.Float, "Feature2"),
+	)
+	dfY := series.New([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, series.Int, "Target")
+
+	fit := func(seed int64) string {
+		dtc := NewDecisionTreeClassifier()
+		dtc.SetMaxFeatures(1)
+		dtc.SetRandomState(seed)
+		dtc.Fit(dfX, dfY)
+		return dtc.tree.String()
+	}
+
+	// The same seed gives the same tree, and a different seed draws different features
+	if fit(1) != fit(1) {
+		t.Errorf("Expected fits with the same random state to be equal")
+	}
+	if fit(1) == fit(2) {
+		t.Errorf("Expected fits with different random states to differ")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Fit to panic, but it did not")
+		}
+	}()
+
+	dtc := NewDecisionTreeClassifier()
+	dtc.SetMaxFeatures(3)
+	dtc.Fit(dfX, dfY)
+}
+
+func TestDecisionTreeClassifier_CostComplexityPruningPath(t *testing.T) {
+	expected := "" +
+		"                 Alpha             Impurity\n" +
+		"0                    0                    0\n" +
+		"1  0.06666666666666667  0.06666666666666667\n" +
+		"2  0.08888888888888889  0.15555555555555556\n" +
+		"3  0.11481481481481481                  0.5"
+
+	dtc := NewDecisionTreeClassifier()
+	dfX := dataframe.New(
+		series.New([]float64{0.9074, 0.9529, 0.5635, 0.9567, 0.8162, 0.3279, 0.0179, 0.4246, 0.4770, 0.3394, 0.0788, 0.4853, 0.4786, 0.2427, 0.4001, 0.8530, 0.5159, 0.6385, 0.5231, 0.5486}, series.Float, "Feature1"),
+		series.New([]float64{0.5488, 0.6392, 0.7734, 0.9788, 0.9824, 0.3789, 0.3716, 0.1961, 0.3277, 0.0856, 0.5709, 0.7109, 0.9579, 0.8961, 0.9797, 0.4117, 0.3474, 0.1585, 0.4751, 0.0172}, series.Float, "Feature2"),
+	)
+	dfY := series.New([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, series.Int, "Target")
+
+	path := dtc.CostComplexityPruningPath(dfX, dfY)
+	if path.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, path.String())
+	}
+
+	if dtc.tree != nil {
+		t.Errorf("Expected CostComplexityPruningPath to leave the DecisionTreeClassifier unfit")
+	}
+}
+
+func TestDecisionTreeClassifier_FitCCPAlpha(t *testing.T) {
+	var expected strings.Builder
+	expected.WriteString("Leafs: 5, Depth: 4\n")
+	expected.WriteString("Axis: 0, Value: 0.8802\n")
+	expected.WriteString("    Axis: 0, Value: 0.4778\n")
+	expected.WriteString("        Axis: 1, Value: 0.4749\n")
+	expected.WriteString("            Leaf: 0\n")
+	expected.WriteString("            Leaf: 1\n")
+	expected.WriteString("        Axis: 1, Value: 0.74215\n")
+	expected.WriteString("            Leaf: 1\n")
+	expected.WriteString("            Leaf: 0\n")
+	expected.WriteString("    Leaf: 0\n")
+
+	dtc := NewDecisionTreeClassifier()
+	dtc.SetCCPAlpha(0.07)
+
+	dfX := dataframe.New(
+		series.New([]float64{0.9074, 0.9529, 0.5635, 0.9567, 0.8162, 0.3279, 0.0179, 0.4246, 0.4770, 0.3394, 0.0788, 0.4853, 0.4786, 0.2427, 0.4001, 0.8530, 0.5159, 0.6385, 0.5231, 0.5486}, series.Float, "Feature1"),
+		series.New([]float64{0.5488, 0.6392, 0.7734, 0.9788, 0.9824, 0.3789, 0.3716, 0.1961, 0.3277, 0.0856, 0.5709, 0.7109, 0.9579, 0.8961, 0.9797, 0.4117, 0.3474, 0.1585, 0.4751, 0.0172}, series.Float, "Feature2"),
+	)
+	dfY := series.New([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, series.Int, "Target")
+
+	dtc.Fit(dfX, dfY)
+
+	if dtc.tree.String() != expected.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected.String(), dtc.tree.String())
+	}
+
+	// An alpha above every effective alpha prunes the tree to its root
+	dtc = NewDecisionTreeClassifier()
+	dtc.SetCCPAlpha(0.2)
+	dtc.Fit(dfX, dfY)
+
+	if dtc.tree.String() != "Leafs: 1, Depth: 1\nLeaf: 0\n" {
+		t.Errorf("Expected a single leaf, got:\n%v", dtc.tree.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected SetCCPAlpha to panic, but it did not")
+		}
+	}()
+
+	dtc.SetCCPAlpha(0.1)
+}
+
 func TestDecisionTreeClassifier_FitGini(t *testing.T) {
 	var expected strings.Builder
 	expected.WriteString("Leafs: 6, Depth: 5\n")
-	expected.WriteString("Axis: 0, Value: 0.9074\n")
-	expected.WriteString("    Axis: 0, Value: 0.4786\n")
-	expected.WriteString("        Axis: 1, Value: 0.5709\n")
+	expected.WriteString("Axis: 0, Value: 0.8802\n")
+	expected.WriteString("    Axis: 0, Value: 0.4778\n")
+	expected.WriteString("        Axis: 1, Value: 0.4749\n")
 	expected.WriteString("            Leaf: 0\n")
 	expected.WriteString("            Leaf: 1\n")
-	expected.WriteString("        Axis: 1, Value: 0.7734\n")
+	expected.WriteString("        Axis: 1, Value: 0.74215\n")
 	expected.WriteString("            Leaf: 1\n")
-	expected.WriteString("            Axis: 0, Value: 0.5635\n")
+	expected.WriteString("            Axis: 0, Value: 0.52105\n")
 	expected.WriteString("                Leaf: 1\n")
 	expected.WriteString("                Leaf: 0\n")
 	expected.WriteString("    Leaf: 0\n")
@@ -92,10 +304,10 @@ func TestDecisionTreeClassifier_FitGini(t *testing.T) {
 func TestDecisionTreeClassifier_FitEntropy(t *testing.T) {
 	var expected strings.Builder
 	expected.WriteString("Leafs: 4, Depth: 4\n")
-	expected.WriteString("Axis: 0, Value: 0.5978\n")
-	expected.WriteString("    Axis: 0, Value: 0.4356\n")
+	expected.WriteString("Axis: 0, Value: 0.5278\n")
+	expected.WriteString("    Axis: 0, Value: 0.37855\n")
 	expected.WriteString("        Leaf: 1\n")
-	expected.WriteString("        Axis: 0, Value: 0.4487\n")
+	expected.WriteString("        Axis: 0, Value: 0.44215\n")
 	expected.WriteString("            Leaf: 0\n")
 	expected.WriteString("            Leaf: 1\n")
 	expected.WriteString("    Leaf: 0\n")
@@ -135,8 +347,181 @@ func TestDecisionTreeClassifier_FitEntropy2(t *testing.T) {
 	dtc.Fit(dfX, dfY)
 }
 
+func TestDecisionTreeClassifier_FitTies(t *testing.T) {
+	var expected strings.Builder
+	expected.WriteString("Leafs: 3, Depth: 3\n")
+	expected.WriteString("Axis: 1, Value: 1.5\n")
+	expected.WriteString("    Leaf: 5\n")
+	expected.WriteString("    Axis: 1, Value: 2.5\n")
+	expected.WriteString("        Leaf: 7\n")
+	expected.WriteString("        Leaf: 5\n")
+
+	dtc := NewDecisionTreeClassifier()
+
+	// Splits are only made between distinct values, and samples which cannot be separated give a majority leaf
+	dfX := dataframe.New(
+		series.New([]float64{1, 1, 1, 1, 1, 1, 1}, series.Float, "Feature1"),
+		series.New([]float64{1, 1, 2, 2, 2, 3, 3}, series.Float, "Feature2"),
+	)
+	dfY := series.New([]int{5, 5, 7, 7, 5, 5, 5}, series.Int, "Target")
+
+	dtc.Fit(dfX, dfY)
+
+	if dtc.tree.String() != expected.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected.String(), dtc.tree.String())
+	}
+}
+
+func TestDecisionTreeClassifier_FitMixedFeatures(t *testing.T) {
+	var expected strings.Builder
+	expected.WriteString("Leafs: 3, Depth: 3\n")
+	expected.WriteString("Axis: 3, Categories: [s m]\n")
+	expected.WriteString("    Axis: 2, Categories: [green]\n")
+	expected.WriteString("        Leaf: 1\n")
+	expected.WriteString("        Leaf: 0\n")
+	expected.WriteString("    Leaf: 2\n")
+
+	dtc := NewDecisionTreeClassifier()
+
+	dfX := dataframe.New(
+		series.New([]int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3}, series.Int, "Count"),
+		series.New([]bool{true, false, true, true, false, false, true, false, true, false}, series.Boolean, "Flag"),
+		series.New([]string{"red", "green", "blue", "green", "red", "blue", "blue", "green", "red", "red"}, series.String, "Color"),
+		series.NewCategorical([]string{"s", "m", "l", "m", "s", "l", "xl", "m", "s", "l"}, []string{"s", "m", "l", "xl"}, "Size"),
+		series.New([]float64{0.2, 0.4, 0.9, 0.1, 0.8, 0.7, 0.3, 0.6, 0.5, 0.4}, series.Float, "Score"),
+	)
+	dfX.Columns()[4].Elem(2).Set(nil)
+	dfX.Columns()[4].Elem(5).Set(nil)
+	dfX.Columns()[4].Elem(9).Set(nil)
+	dfY := series.New([]int{0, 1, 2, 1, 0, 2, 2, 1, 0, 2}, series.Int, "Target")
+
+	dtc.Fit(dfX, dfY)
+
+	if dtc.tree.String() != expected.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected.String(), dtc.tree.String())
+	}
+
+	// Categories which were not seen when fitting are sent right, and NA values which were not seen when fitting are
+	// sent to the child with more samples
+	dfPredict := dataframe.New(
+		series.New([]int{1, 2, 3}, series.Int, "Count"),
+		series.New([]bool{true, false, true}, series.Boolean, "Flag"),
+		series.New([]string{"green", "purple", "red"}, series.String, "Color"),
+		series.NewCategorical([]string{"m", "s", "xl"}, []string{"s", "m", "l", "xl"}, "Size"),
+		series.New([]float64{0.5, 0.5, 0.5}, series.Float, "Score"),
+	)
+	dfPredict.Columns()[3].Elem(2).Set(nil)
+
+	if text := dtc.ExportText(); !strings.HasPrefix(text, "if Size in [s m] then\n    if Color in [green] then\n") {
+		t.Errorf("Expected categorical conditions, got:\n%v", text)
+	}
+
+	expectedPredictions := "{Target [1 0 0] int}"
+	if predictions := dtc.Predict(dfPredict); predictions.String() != expectedPredictions {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expectedPredictions, predictions.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Fit to panic, but it did not")
+		}
+	}()
+
+	dfY.Elem(0).Set(nil)
+	dtc.Fit(dfX, dfY)
+}
+
+func TestDecisionTreeClassifier_FitMissing(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		missing  []int
+		labels   []int
+		expected string
+	}{
+		{
+			name:     "MissingLeft",
+			values:   []float64{1, 2, 0, 0, 5, 6},
+			missing:  []int{2, 3},
+			labels:   []int{1, 1, 1, 1, 0, 0},
+			expected: "Leafs: 2, Depth: 2\nAxis: 0, Value: 3.5, Missing: Left\n    Leaf: 1\n    Leaf: 0\n",
+		},
+		{
+			name:     "MissingAlone",
+			values:   []float64{1, 2, 3, 0, 0},
+			missing:  []int{3, 4},
+			labels:   []int{0, 0, 0, 1, 1},
+			expected: "Leafs: 2, Depth: 2\nAxis: 0, Value: +Inf\n    Leaf: 0\n    Leaf: 1\n",
+		},
+	}
+
+	for _, test := range tests {
+		dfX := dataframe.New(series.New(test.values, series.Float, "Feature1"))
+		for _, i := range test.missing {
+			dfX.Columns()[0].Elem(i).Set(nil)
+		}
+
+		dtc := NewDecisionTreeClassifier()
+		dtc.Fit(dfX, series.New(test.labels, series.Int, "Target"))
+
+		if dtc.tree.String() != test.expected {
+			t.Errorf("%v Expected:\n%v\nGot:\n%v", test.name, test.expected, dtc.tree.String())
+		}
+
+		// Samples with NA values follow the learnt direction
+		predictions := dtc.Predict(dfX)
+		for _, i := range test.missing {
+			if predictions.Val(i) != 1 {
+				t.Errorf("%v Expected NA sample %v to be predicted as 1, got %v", test.name, i, predictions.Val(i))
+			}
+		}
+	}
+}
+
+func TestDecisionTreeClassifier_PredictUnseenMissing(t *testing.T) {
+	tests := []struct {
+		name     string
+		weights  []float64
+		expected string
+	}{
+		{
+			name:     "LeftHeavier",
+			weights:  []float64{1, 1, 1, 1, 1, 1},
+			expected: "{Target [0] int}",
+		},
+		{
+			name:     "RightHeavier",
+			weights:  []float64{1, 1, 1, 1, 5, 5},
+			expected: "{Target [1] int}",
+		},
+	}
+
+	dfX := dataframe.New(series.New([]float64{1, 2, 3, 4, 5, 6}, series.Float, "Feature1"))
+	dfY := series.New([]int{0, 0, 0, 0, 1, 1}, series.Int, "Target")
+
+	dfPredict := dataframe.New(series.New([]float64{0}, series.Float, "Feature1"))
+	dfPredict.Columns()[0].Elem(0).Set(nil)
+
+	// NA values which were not seen when fitting are sent to the child with the larger weight of samples, but the
+	// direction is not shown as it was not learnt
+	for _, test := range tests {
+		dtc := NewDecisionTreeClassifier()
+		dtc.FitWeighted(dfX, dfY, series.New(test.weights, series.Float, "Weight"))
+
+		if predictions := dtc.Predict(dfPredict); predictions.String() != test.expected {
+			t.Errorf("%v Expected:\n%v\nGot:\n%v", test.name, test.expected, predictions.String())
+		}
+		if tree := dtc.tree.String(); strings.Contains(tree, "Missing") {
+			t.Errorf("%v Expected no missing direction, got:\n%v", test.name, tree)
+		}
+		if text := dtc.ExportText(); strings.Contains(text, "is NA") {
+			t.Errorf("%v Expected no missing condition, got:\n%v", test.name, text)
+		}
+	}
+}
+
 func TestDecisionTreeClassifier_Predict(t *testing.T) {
-	expected := "{Target [1 1 0 1 1 0 1 1] int}"
+	expected := "{Target [1 1 0 1 0 0 1 1] int}"
 
 	dtc := NewDecisionTreeClassifier()
 	dtc.SetCriterion("entropy")
@@ -159,6 +544,454 @@ func TestDecisionTreeClassifier_Predict(t *testing.T) {
 	}
 }
 
+func TestDecisionTreeClassifier_PredictMaxDepth(t *testing.T) {
+	var expected strings.Builder
+	expected.WriteString("Leafs: 2, Depth: 2\n")
+	expected.WriteString("Axis: 0, Value: 0.8802\n")
+	expected.WriteString("    Leaf: 1\n")
+	expected.WriteString("    Leaf: 0\n")
+
+	dtc := NewDecisionTreeClassifier()
+	dtc.SetMaxDepth(2)
+
+	dfX := dataframe.New(
+		series.New([]float64{0.9074, 0.9529, 0.5635, 0.9567, 0.8162, 0.3279, 0.0179, 0.4246, 0.4770, 0.3394, 0.0788, 0.4853, 0.4786, 0.2427, 0.4001, 0.8530, 0.5159, 0.6385, 0.5231, 0.5486}, series.Float, "Feature1"),
+		series.New([]float64{0.5488, 0.6392, 0.7734, 0.9788, 0.9824, 0.3789, 0.3716, 0.1961, 0.3277, 0.0856, 0.5709, 0.7109, 0.9579, 0.8961, 0.9797, 0.4117, 0.3474, 0.1585, 0.4751, 0.0172}, series.Float, "Feature2"),
+	)
+	dfY := series.New([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, series.Int, "Target")
+
+	dtc.Fit(dfX, dfY)
+
+	// Nodes at the maximum depth are leaves of the most common class
+	if dtc.tree.String() != expected.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected.String(), dtc.tree.String())
+	}
+
+	expectedCounts := "[10 10] [7 10] [3 0]"
+	counts := fmt.Sprintf("%v %v %v", dtc.tree.Counts, dtc.tree.Left.Counts, dtc.tree.Right.Counts)
+	if counts != expectedCounts {
+		t.Errorf("Expected counts %v, got %v", expectedCounts, counts)
+	}
+
+	expectedPredictions := "{Target [0 0 1 0] int}"
+	predictions := dtc.Predict(dfX.Head(4))
+	if predictions.String() != expectedPredictions {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expectedPredictions, predictions.String())
+	}
+}
+
+func TestDecisionTreeClassifier_PredictProbability(t *testing.T) {
+	expected := "" +
+		"                    0                   1\n" +
+		"0                   1                   0\n" +
+		"1                   1                   0\n" +
+		"2  0.4117647058823529  0.5882352941176471\n" +
+		"3                   1                   0"
+
+	dtc := NewDecisionTreeClassifier()
+	dtc.SetMaxDepth(2)
+
+	dfX := dataframe.New(
+		series.New([]float64{0.9074, 0.9529, 0.5635, 0.9567, 0.8162, 0.3279, 0.0179, 0.4246, 0.4770, 0.3394, 0.0788, 0.4853, 0.4786, 0.2427, 0.4001, 0.8530, 0.5159, 0.6385, 0.5231, 0.5486}, series.Float, "Feature1"),
+		series.New([]float64{0.5488, 0.6392, 0.7734, 0.9788, 0.9824, 0.3789, 0.3716, 0.1961, 0.3277, 0.0856, 0.5709, 0.7109, 0.9579, 0.8961, 0.9797, 0.4117, 0.3474, 0.1585, 0.4751, 0.0172}, series.Float, "Feature2"),
+	)
+	dfY := series.New([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, series.Int, "Target")
+
+	dtc.Fit(dfX, dfY)
+
+	probabilities := dtc.PredictProbability(dfX.Head(4))
+	if probabilities.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, probabilities.String())
+	}
+
+	if classes := dtc.Classes(); len(classes) != 2 || classes[0] != 0 || classes[1] != 1 {
+		t.Errorf("Expected classes [0 1], got %v", classes)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected PredictProbability to panic, but it did not")
+		}
+	}()
+
+	NewDecisionTreeClassifier().PredictProbability(dfX)
+}
+
+func TestDecisionTreeClassifier_PredictColumns(t *testing.T) {
+	dfX := dataframe.New(
+		series.New([]float64{1, 2, 3, 4}, series.Float, "Feature1"),
+		series.New([]float64{4, 3, 2, 1}, series.Float, "Feature2"),
+	)
+	dfY := series.New([]int{0, 0, 1, 1}, series.Int, "Target")
+
+	dtc := NewDecisionTreeClassifier()
+	dtc.Fit(dfX, dfY)
+
+	// Frames with more or fewer columns than were fit are rejected like frames with other column names
+	frames := map[string]dataframe.DataFrame{
+		"more": dataframe.New(
+			series.New([]float64{1, 2, 3, 4}, series.Float, "Feature1"),
+			series.New([]float64{4, 3, 2, 1}, series.Float, "Feature2"),
+			series.New([]float64{0, 0, 0, 0}, series.Float, "Feature3"),
+		),
+		"fewer": dataframe.New(series.New([]float64{1, 2, 3, 4}, series.Float, "Feature1")),
+		"names": dataframe.New(
+			series.New([]float64{1, 2, 3, 4}, series.Float, "Feature2"),
+			series.New([]float64{4, 3, 2, 1}, series.Float, "Feature1"),
+		),
+	}
+	methods := map[string]func(df dataframe.DataFrame){
+		"Predict":            func(df dataframe.DataFrame) { dtc.Predict(df) },
+		"PredictProbability": func(df dataframe.DataFrame) { dtc.PredictProbability(df) },
+		"Apply":              func(df dataframe.DataFrame) { dtc.Apply(df) },
+		"DecisionPath":       func(df dataframe.DataFrame) { dtc.DecisionPath(df) },
+	}
+
+	for frame, df := range frames {
+		for method, f := range methods {
+			t.Run(frame+method, func(t *testing.T) {
+				defer func() {
+					if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "fit column") {
+						t.Errorf("Expected panic for columns not matching the fit columns, got %v", r)
+					}
+				}()
+
+				f(df)
+			})
+		}
+	}
+}
+
+func TestDecisionTreeClassifier_Introspection(t *testing.T) {
+	dtc := NewDecisionTreeClassifier()
+	dtc.SetMaxDepth(4)
+
+	dfX := dataframe.New(
+		series.New([]float64{0.9074, 0.9529, 0.5635, 0.9567, 0.8162, 0.3279, 0.0179, 0.4246, 0.4770, 0.3394, 0.0788, 0.4853, 0.4786, 0.2427, 0.4001, 0.8530, 0.5159, 0.6385, 0.5231, 0.5486}, series.Float, "Feature1"),
+		series.New([]float64{0.5488, 0.6392, 0.7734, 0.9788, 0.9824, 0.3789, 0.3716, 0.1961, 0.3277, 0.0856, 0.5709, 0.7109, 0.9579, 0.8961, 0.9797, 0.4117, 0.3474, 0.1585, 0.4751, 0.0172}, series.Float, "Feature2"),
+	)
+	dfY := series.New([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, series.Int, "Target")
+
+	dtc.Fit(dfX, dfY)
+
+	expectedImportances := "map[Feature1:0.36217948717948717 Feature2:0.6378205128205129]"
+	if importances := fmt.Sprintf("%v", dtc.FeatureImportances()); importances != expectedImportances {
+		t.Errorf("Expected importances %v, got %v", expectedImportances, importances)
+	}
+
+	if depth := dtc.GetDepth(); depth != 4 {
+		t.Errorf("Expected depth 4, got %v", depth)
+	}
+
+	if leaves := dtc.GetNLeaves(); leaves != 5 {
+		t.Errorf("Expected 5 leaves, got %v", leaves)
+	}
+
+	// Nodes are numbered depth first, so the third sample passes through the left and then right branches
+	expectedLeaves := "{Leaf [8 8 7 8] int}"
+	if leaves := dtc.Apply(dfX.Head(4)); leaves.String() != expectedLeaves {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expectedLeaves, leaves.String())
+	}
+
+	expectedPaths := "[[0 8] [0 8] [0 1 5 7] [0 8]]"
+	if paths := fmt.Sprintf("%v", dtc.DecisionPath(dfX.Head(4))); paths != expectedPaths {
+		t.Errorf("Expected paths %v, got %v", expectedPaths, paths)
+	}
+
+	if dtc.tree.Samples != 20 || dtc.tree.Impurity != 0.5 || dtc.tree.Left.Samples != 17 {
+		t.Errorf("Expected root with 20 samples and impurity 0.5 and left child with 17 samples, got %v, %v and %v",
+			dtc.tree.Samples, dtc.tree.Impurity, dtc.tree.Left.Samples)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected GetDepth to panic, but it did not")
+		}
+	}()
+
+	NewDecisionTreeClassifier().GetDepth()
+}
+
+func TestDecisionTreeClassifier_ExportText(t *testing.T) {
+	var expected strings.Builder
+	expected.WriteString("if Feature1 <= 0.8802 then\n")
+	expected.WriteString("    if Feature1 <= 0.4778 then\n")
+	expected.WriteString("        class = 0 (samples = 8)\n")
+	expected.WriteString("    else\n")
+	expected.WriteString("        class = 1 (samples = 9)\n")
+	expected.WriteString("else\n")
+	expected.WriteString("    class = 0 (samples = 3)\n")
+
+	dtc := NewDecisionTreeClassifier()
+	dtc.SetMaxDepth(3)
+
+	dfX := dataframe.New(
+		series.New([]float64{0.9074, 0.9529, 0.5635, 0.9567, 0.8162, 0.3279, 0.0179, 0.4246, 0.4770, 0.3394, 0.0788, 0.4853, 0.4786, 0.2427, 0.4001, 0.8530, 0.5159, 0.6385, 0.5231, 0.5486}, series.Float, "Feature1"),
+		series.New([]float64{0.5488, 0.6392, 0.7734, 0.9788, 0.9824, 0.3789, 0.3716, 0.1961, 0.3277, 0.0856, 0.5709, 0.7109, 0.9579, 0.8961, 0.9797, 0.4117, 0.3474, 0.1585, 0.4751, 0.0172}, series.Float, "Feature2"),
+	)
+	dfY := series.New([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, series.Int, "Target")
+
+	dtc.Fit(dfX, dfY)
+
+	if text := dtc.ExportText(); text != expected.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected.String(), text)
+	}
+}
+
+func TestDecisionTreeClassifier_ExportGraphviz(t *testing.T) {
+	var expected strings.Builder
+	expected.WriteString("digraph Tree {\n")
+	expected.WriteString("node [shape=box, style=\"filled, rounded\", fontname=\"helvetica\"] ;\n")
+	expected.WriteString("edge [fontname=\"helvetica\"] ;\n")
+	expected.WriteString("0 [label=\"Feature1 <= 0.8802\\ngini = 0.5\\nsamples = 20\\nvalue = [10 10]\\nclass = 0\", ")
+	expected.WriteString("fillcolor=\"#ffffff\"] ;\n")
+	expected.WriteString("1 [label=\"gini = 0.4844290657439447\\nsamples = 17\\nvalue = [7 10]\\nclass = 1\", ")
+	expected.WriteString("fillcolor=\"#c4e2f7\"] ;\n")
+	expected.WriteString("0 -> 1 [labeldistance=2.5, labelangle=45, headlabel=\"True\"] ;\n")
+	expected.WriteString("2 [label=\"gini = 0\\nsamples = 3\\nvalue = [3 0]\\nclass = 0\", fillcolor=\"#e58139\"] ;\n")
+	expected.WriteString("0 -> 2 [labeldistance=2.5, labelangle=-45, headlabel=\"False\"] ;\n")
+	expected.WriteString("}\n")
+
+	dtc := NewDecisionTreeClassifier()
+	dtc.SetMaxDepth(2)
+
+	dfX := dataframe.New(
+		series.New([]float64{0.9074, 0.9529, 0.5635, 0.9567, 0.8162, 0.3279, 0.0179, 0.4246, 0.4770, 0.3394, 0.0788, 0.4853, 0.4786, 0.2427, 0.4001, 0.8530, 0.5159, 0.6385, 0.5231, 0.5486}, series.Float, "Feature1"),
+		series.New([]float64{0.5488, 0.6392, 0.7734, 0.9788, 0.9824, 0.3789, 0.3716, 0.1961, 0.3277, 0.0856, 0.5709, 0.7109, 0.9579, 0.8961, 0.9797, 0.4117, 0.3474, 0.1585, 0.4751, 0.0172}, series.Float, "Feature2"),
+	)
+	dfY := series.New([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, series.Int, "Target")
+
+	dtc.Fit(dfX, dfY)
+
+	if dot := dtc.ExportGraphviz(); dot != expected.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected.String(), dot)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected ExportGraphviz to panic, but it did not")
+		}
+	}()
+
+	NewDecisionTreeClassifier().ExportGraphviz()
+}
+
+// duplicateRows returns the positions of the rows repeated by the integer weight of each row
+func duplicateRows(weights []float64) []int {
+	rows := make([]int, 0)
+	for i, w := range weights {
+		for j := 0; j < int(w); j++ {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+func TestDecisionTreeClassifier_FitWeighted(t *testing.T) {
+	// Integer weights give the same tree as repeating each sample by its weight
+	weights := []float64{1, 2, 1, 3, 1, 1, 2, 1, 1, 4}
+	dfX := dataframe.New(
+		series.New([]int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3}, series.Int, "Count"),
+		series.New([]bool{true, false, true, true, false, false, true, false, true, false}, series.Boolean, "Flag"),
+		series.New([]string{"red", "green", "blue", "green", "red", "blue", "blue", "green", "red", "red"}, series.String, "Color"),
+		series.NewCategorical([]string{"s", "m", "l", "m", "s", "l", "xl", "m", "s", "l"}, []string{"s", "m", "l", "xl"}, "Size"),
+		series.New([]float64{0.2, 0.4, 0.9, 0.1, 0.8, 0.7, 0.3, 0.6, 0.5, 0.4}, series.Float, "Score"),
+	)
+	dfX.Columns()[4].Elem(2).Set(nil)
+	dfX.Columns()[4].Elem(5).Set(nil)
+	dfX.Columns()[4].Elem(9).Set(nil)
+	dfY := series.New([]int{0, 1, 2, 1, 0, 2, 2, 1, 0, 2}, series.Int, "Target")
+
+	weighted := NewDecisionTreeClassifier()
+	weighted.FitWeighted(dfX, dfY, series.New(weights, series.Float, "Weight"))
+
+	rows := duplicateRows(weights)
+	duplicated := NewDecisionTreeClassifier()
+	duplicated.Fit(dfX.Take(rows...), dfY.Take(rows...))
+
+	if weighted.tree.String() != duplicated.tree.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", duplicated.tree.String(), weighted.tree.String())
+	}
+
+	expected := fmt.Sprint(duplicated.PredictProbability(dfX))
+	if got := fmt.Sprint(weighted.PredictProbability(dfX)); got != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, got)
+	}
+
+	if weighted.tree.Samples != 10 || weighted.tree.WeightedSamples != 17 {
+		t.Errorf("Expected 10 samples with weight 17, got %v with weight %v", weighted.tree.Samples,
+			weighted.tree.WeightedSamples)
+	}
+
+	expectedPath := fmt.Sprint(duplicated.CostComplexityPruningPath(dfX.Take(rows...), dfY.Take(rows...)))
+	path := fmt.Sprint(weighted.CostComplexityPruningPath(dfX, dfY, series.New(weights, series.Float, "Weight")))
+	if path != expectedPath {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expectedPath, path)
+	}
+
+	invalid := map[string]series.Series{
+		"length":   series.New([]float64{1, 1}, series.Float, "Weight"),
+		"negative": series.New([]float64{1, 1, 1, 1, -1, 1, 1, 1, 1, 1}, series.Float, "Weight"),
+		"zero":     series.New([]float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, series.Float, "Weight"),
+		"type":     series.New([]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}, series.String, "Weight"),
+		"NA":       series.New([]float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, series.Float, "Weight"),
+	}
+	invalid["NA"].Elem(3).Set(nil)
+
+	for name, w := range invalid {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic for invalid sample weights")
+				}
+			}()
+
+			NewDecisionTreeClassifier().FitWeighted(dfX, dfY, w)
+		})
+	}
+}
+
+func TestDecisionTreeClassifier_SetClassWeight(t *testing.T) {
+	// The samples cannot be separated, so the root is a leaf predicting the class with the largest total weight
+	dfX := dataframe.New(series.New([]float64{1, 1, 1, 1}, series.Float, "Feature"))
+	dfY := series.New([]int{0, 0, 0, 1}, series.Int, "Target")
+
+	dtc := NewDecisionTreeClassifier()
+	dtc.Fit(dfX, dfY)
+	if dtc.tree.Label != 0 {
+		t.Errorf("Expected unweighted label 0, got %v", dtc.tree.Label)
+	}
+
+	dtc = NewDecisionTreeClassifier()
+	dtc.SetClassWeight(map[int]float64{1: 4})
+	dtc.Fit(dfX, dfY)
+	if dtc.tree.Label != 1 || fmt.Sprint(dtc.tree.Counts) != "[3 4]" {
+		t.Errorf("Expected label 1 with counts [3 4], got %v with counts %v", dtc.tree.Label, dtc.tree.Counts)
+	}
+
+	// Balanced class weights give every class the same total weight
+	dtc = NewDecisionTreeClassifier()
+	dtc.SetBalancedClassWeight()
+	dtc.FitWeighted(dfX, dfY, series.New([]int{1, 1, 1, 1}, series.Int, "Weight"))
+	if fmt.Sprint(dtc.tree.Counts) != "[2 2]" {
+		t.Errorf("Expected counts [2 2], got %v", dtc.tree.Counts)
+	}
+
+	t.Run("negative", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Expected panic for negative class weight")
+			}
+		}()
+
+		NewDecisionTreeClassifier().SetClassWeight(map[int]float64{0: -1})
+	})
+
+	t.Run("unknown class", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Expected panic for class weight of unknown class")
+			}
+		}()
+
+		dtc := NewDecisionTreeClassifier()
+		dtc.SetClassWeight(map[int]float64{2: 1})
+		dtc.Fit(dfX, dfY)
+	})
+}
+
+// costCriterion is a Gini impurity where the term of each class is scaled by the cost of misclassifying it, keeping
+// running totals of the children
+type costCriterion struct {
+	costs       []float64
+	total, left []float64
+}
+
+func (c *costCriterion) Init(total []float64) {
+	c.total = total
+	c.left = make([]float64, len(total))
+}
+
+func (c *costCriterion) Update(delta []float64) {
+	for k, d := range delta {
+		c.left[k] += d
+	}
+}
+
+func (c *costCriterion) impurity(counts []float64) float64 {
+	n := 0.0
+	for _, w := range counts {
+		n += w
+	}
+
+	impurity := 0.0
+	for k, w := range counts {
+		impurity += c.costs[k] * w / n * (1 - w/n)
+	}
+	return impurity
+}
+
+func (c *costCriterion) NodeImpurity() float64 {
+	return c.impurity(c.total)
+}
+
+func (c *costCriterion) ChildrenImpurity() (float64, float64) {
+	right := make([]float64, len(c.total))
+	for k := range right {
+		right[k] = c.total[k] - c.left[k]
+	}
+	return c.impurity(c.left), c.impurity(right)
+}
+
+func TestDecisionTreeClassifier_SetCriterionFunction(t *testing.T) {
+	dfX := dataframe.New(
+		series.New([]int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3}, series.Int, "Count"),
+		series.New([]bool{true, false, true, true, false, false, true, false, true, false}, series.Boolean, "Flag"),
+		series.New([]string{"red", "green", "blue", "green", "red", "blue", "blue", "green", "red", "red"}, series.String, "Color"),
+		series.NewCategorical([]string{"s", "m", "l", "m", "s", "l", "xl", "m", "s", "l"}, []string{"s", "m", "l", "xl"}, "Size"),
+		series.New([]float64{0.2, 0.4, 0.9, 0.1, 0.8, 0.7, 0.3, 0.6, 0.5, 0.4}, series.Float, "Score"),
+	)
+	dfX.Columns()[4].Elem(2).Set(nil)
+	dfX.Columns()[4].Elem(5).Set(nil)
+	dfX.Columns()[4].Elem(9).Set(nil)
+	dfY := series.New([]int{0, 1, 2, 1, 0, 2, 2, 1, 0, 2}, series.Int, "Target")
+
+	// Equal costs give the Gini impurity
+	expected := NewDecisionTreeClassifier()
+	expected.Fit(dfX, dfY)
+
+	dtc := NewDecisionTreeClassifier()
+	dtc.SetCriterionFunction(&costCriterion{costs: []float64{1, 1, 1}})
+	if dtc.criterionString != "custom" {
+		t.Errorf("Expected criterion to be custom, got %v", dtc.criterionString)
+	}
+	dtc.Fit(dfX, dfY)
+
+	if dtc.tree.String() != expected.tree.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected.tree.String(), dtc.tree.String())
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Expected panic for nil criterion")
+			}
+		}()
+
+		NewDecisionTreeClassifier().SetCriterionFunction(nil)
+	})
+
+	t.Run("after fit", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Expected panic when setting criterion after fit")
+			}
+		}()
+
+		dtc.SetCriterionFunction(&costCriterion{costs: []float64{1, 1, 1}})
+	})
+}
+
 func TestDecisionTreeClassifier_IsClassifier(t *testing.T) {
 	dtc := NewDecisionTreeClassifier()
 
@@ -174,3 +1007,40 @@ func TestDecisionTreeClassifier_IsRegressor(t *testing.T) {
 		t.Errorf("Expected IsRegressor to return false, got true")
 	}
 }
+
+// newBenchmarkData creates n samples of random features, with a binary target which depends on the first feature
+func newBenchmarkData(n, features int) (dataframe.DataFrame, series.Series) {
+	r := rand.New(rand.NewSource(1))
+
+	columns := make([]series.Series, features)
+	for j := range columns {
+		values := make([]float64, n)
+		for i := range values {
+			values[i] = r.Float64()
+		}
+		columns[j] = series.New(values, series.Float, fmt.Sprintf("Feature%v", j+1))
+	}
+
+	labels := make([]int, n)
+	for i := range labels {
+		if columns[0].Val(i).(float64)+0.2*r.NormFloat64() > 0.5 {
+			labels[i] = 1
+		}
+	}
+
+	return dataframe.New(columns...), series.New(labels, series.Int, "Target")
+}
+
+func BenchmarkDecisionTreeClassifier_Fit(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000, 100000} {
+		dfX, dfY := newBenchmarkData(n, 5)
+
+		b.Run(fmt.Sprintf("Samples%v", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dtc := NewDecisionTreeClassifier()
+				dtc.SetMaxDepth(8)
+				dtc.Fit(dfX, dfY)
+			}
+		})
+	}
+}
@@ -7,18 +7,9 @@ import (
 	"github.com/chriso345/golab/dataframe/series"
 )
 
-// DecisionTreeRegressor is a struct that represents a decision tree classifier
+// DecisionTreeRegressor is a struct that represents a decision tree regressor
 type DecisionTreeRegressor struct {
-	maxDepth int
-	// minSamplesSplit int
-	// minSamplesLeaf int
-
-	criterionString string
-	criterion       criterionFunction
-	tree            *DecisionTree
-
-	features []string
-	target   string
+	baseTree
 }
 
 // force implementation of Model interface
@@ -26,69 +17,70 @@ var _ golab.Model = (*DecisionTreeRegressor)(nil)
 
 // NewDecisionTreeRegressor creates a new DecisionTreeRegressor with default values
 func NewDecisionTreeRegressor() *DecisionTreeRegressor {
-	return &DecisionTreeRegressor{
-		criterionString: "mse",
-		//criterion:       mse, // TODO implement me
-		maxDepth:        -1,
-		tree:            nil,
-	}
+	return &DecisionTreeRegressor{baseTree: newBaseTree("mse", mse)}
 }
 
-// SetCriterion sets the criterion for the DecisionTreeRegressor
+// SetCriterion sets the criterion for the DecisionTreeRegressor, which is mse
 func (dtr *DecisionTreeRegressor) SetCriterion(criterion string) {
-	if dtr.tree != nil {
-		panic(fmt.Errorf("cannot set criterion after fit"))
-	}
+	dtr.setCriterion(criterion, map[string]criterionFunction{
+		"mse": mse,
+	})
+}
 
-	criterionStrings := []string{"mse"}
-	possibleCriteria := map[string]criterionFunction{
-		//"mse": mse, // TODO implement me
-	}
+// grow grows an unpruned DecisionTree from the data, with the weight of each sample if given
+func (dtr DecisionTreeRegressor) grow(dfX dataframe.DataFrame, dfY series.Series,
+	sampleWeight ...series.Series) *DecisionTree {
+	checkFit(dfX, dfY)
 
-	for k, c := range possibleCriteria {
-		if k == criterion {
-			dtr.criterion = c
-			dtr.criterionString = criterion
-			return
-		}
+	if !dfY.IsNumeric() {
+		panic(fmt.Errorf("cannot fit with target of type %v", dfY.Type()))
 	}
 
-	panic(fmt.Errorf("criterion must be one of %v, but got %v", criterionStrings, criterion))
-}
-
-// SetCriterionFunction sets the criterion for the DecisionTreeRegressor from a criterion function
-func (dtr DecisionTreeRegressor) SetCriterionFunction(criterion criterionFunction) {
-	if dtr.tree != nil {
-		panic(fmt.Errorf("cannot set criterion after fit"))
-	}
+	weights := sampleWeights(sampleWeight, dfY.Len())
+	checkWeights(weights)
 
-	dtr.criterion = criterion
-	dtr.criterionString = "custom"
+	return dtr.build(featureValues(dfX), valueTarget{y: dfY.Floats(), weights: weights})
 }
 
-// SetMaxDepth sets the maximum depth of the DecisionTreeRegressor
-func (dtr *DecisionTreeRegressor) SetMaxDepth(maxDepth int) {
-	if dtr.tree != nil {
-		panic(fmt.Errorf("cannot set maxDepth after fit"))
-	}
+// Fit fits the DecisionTreeRegressor to the data and creates the DecisionTree, which is pruned if ccpAlpha is set
+func (dtr *DecisionTreeRegressor) Fit(dfX dataframe.DataFrame, dfY series.Series) {
+	dtr.fit(dfX, dfY)
+}
 
-	if maxDepth < -1 || maxDepth == 0 { // Maybe maxDepth of 0 should be allowed? Unsure of the implications
-		panic(fmt.Errorf("maxDepth must be greater than 0 or -1 for no limit, but got %v", maxDepth))
-	}
+// FitWeighted fits the DecisionTreeRegressor to the data like Fit, with a non-negative weight for each sample. Each
+// sample counts in the impurity and mean of the nodes it reaches in proportion to its weight
+func (dtr *DecisionTreeRegressor) FitWeighted(dfX dataframe.DataFrame, dfY series.Series, sampleWeight series.Series) {
+	dtr.fit(dfX, dfY, sampleWeight)
+}
 
-	dtr.maxDepth = maxDepth
+// fit fits the DecisionTreeRegressor to the data, with the weight of each sample if given
+func (dtr *DecisionTreeRegressor) fit(dfX dataframe.DataFrame, dfY series.Series, sampleWeight ...series.Series) {
+	dtr.setTree(dtr.grow(dfX, dfY, sampleWeight...), dfX, dfY)
 }
 
-// Fit fits the DecisionTreeRegressor to the data
-func (dtr DecisionTreeRegressor) Fit(dfX dataframe.DataFrame, dfY series.Series) {
-	//TODO implement me
-	panic("implement me")
+// CostComplexityPruningPath grows a DecisionTree from the data with the settings of the DecisionTreeRegressor, and
+// prunes it to the root by minimal cost-complexity pruning. The result has the effective alpha of each step in column
+// Alpha, and the total impurity of the leaves of the pruned tree in column Impurity. Setting ccpAlpha to an alpha of
+// the path fits the tree pruned at that step, leaving the DecisionTreeRegressor unchanged. The weight of each sample
+// may be given as for FitWeighted
+func (dtr DecisionTreeRegressor) CostComplexityPruningPath(dfX dataframe.DataFrame, dfY series.Series,
+	sampleWeight ...series.Series) dataframe.DataFrame {
+	return costComplexityPruningPath(dtr.grow(dfX, dfY, sampleWeight...))
 }
 
-// Predict predicts the target values for the given data
+// Predict predicts the target values for the given data, as the mean target of the fit samples in the leaf reached by
+// each sample
 func (dtr DecisionTreeRegressor) Predict(df dataframe.DataFrame) series.Series {
-	//TODO implement me
-	panic("implement me")
+	dtr.checkPredict(df)
+
+	numSamples, _ := df.Shape()
+
+	predictions := make([]float64, numSamples)
+	for i := 0; i < numSamples; i++ {
+		predictions[i] = dtr.tree.leaf(df, i).Output
+	}
+
+	return series.New(predictions, series.Float, dtr.target)
 }
 
 // IsClassifier returns whether the model is a classifier
@@ -0,0 +1,408 @@
+package tree
+
+import (
+	"github.com/chriso345/golab/dataframe"
+	"github.com/chriso345/golab/dataframe/series"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNewDecisionTreeRegressor(t *testing.T) {
+	dtr := NewDecisionTreeRegressor()
+
+	if dtr.criterionString != "mse" {
+		t.Errorf("Expected criterion to be mse, got %v", dtr.criterionString)
+	}
+
+	if dtr.maxDepth != -1 {
+		t.Errorf("Expected maxDepth to be -1, got %v", dtr.maxDepth)
+	}
+
+	if dtr.tree != nil {
+		t.Errorf("Expected tree to be nil, got %v", dtr.tree)
+	}
+}
+
+func TestDecisionTreeRegressor_SetCriterion(t *testing.T) {
+	dtr := NewDecisionTreeRegressor()
+
+	dtr.SetCriterion("mse")
+
+	if dtr.criterionString != "mse" {
+		t.Errorf("Expected criterion to be mse, got %v", dtr.criterionString)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected SetCriterion to panic, but it did not")
+		}
+	}()
+
+	dtr.SetCriterion("not a valid criterion")
+}
+
+func TestDecisionTreeRegressor_SetGrowthControls(t *testing.T) {
+	dtr := NewDecisionTreeRegressor()
+
+	dtr.SetMinSamplesSplit(4)
+	dtr.SetMinSamplesLeaf(2)
+	dtr.SetMinImpurityDecrease(0.01)
+	dtr.SetMaxLeafNodes(8)
+	dtr.SetMaxFeatures(1)
+	dtr.SetRandomState(42)
+
+	if dtr.minSamplesSplit != 4 || dtr.minSamplesLeaf != 2 || dtr.minImpurityDecrease != 0.01 ||
+		dtr.maxLeafNodes != 8 || dtr.maxFeatures != 1 || dtr.randomState != 42 {
+		t.Errorf("Expected growth controls to be set, got %+v", dtr)
+	}
+
+	invalid := map[string]func(){
+		"SetMinSamplesSplit":     func() { dtr.SetMinSamplesSplit(1) },
+		"SetMinSamplesLeaf":      func() { dtr.SetMinSamplesLeaf(0) },
+		"SetMinImpurityDecrease": func() { dtr.SetMinImpurityDecrease(-0.1) },
+		"SetMaxLeafNodes":        func() { dtr.SetMaxLeafNodes(1) },
+		"SetMaxFeatures":         func() { dtr.SetMaxFeatures(0) },
+		"SetRandomState":         func() { dtr.SetRandomState(-5) },
+	}
+
+	for name, f := range invalid {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected %v to panic, but it did not", name)
+				}
+			}()
+
+			f()
+		}()
+	}
+}
+
+func TestDecisionTreeRegressor_FitGrowthControls(t *testing.T) {
+	tests := []struct {
+		name     string
+		set      func(dtr *DecisionTreeRegressor)
+		expected string
+	}{
+		{
+			name:     "MinSamplesSplit",
+			set:      func(dtr *DecisionTreeRegressor) { dtr.SetMinSamplesSplit(9) },
+			expected: "Leafs: 1, Depth: 1\nLeaf: 4.8125\n",
+		},
+		{
+			name: "MinSamplesLeaf",
+			set:  func(dtr *DecisionTreeRegressor) { dtr.SetMinSamplesLeaf(3) },
+			expected: "Leafs: 2, Depth: 2\n" +
+				"Axis: 0, Value: 3.5\n" +
+				"    Leaf: 1\n" +
+				"    Leaf: 7.1\n",
+		},
+		{
+			name:     "MinImpurityDecrease",
+			set:      func(dtr *DecisionTreeRegressor) { dtr.SetMinImpurityDecrease(100) },
+			expected: "Leafs: 1, Depth: 1\nLeaf: 4.8125\n",
+		},
+		{
+			name: "MaxLeafNodes",
+			set:  func(dtr *DecisionTreeRegressor) { dtr.SetMaxLeafNodes(3) },
+			expected: "Leafs: 3, Depth: 3\n" +
+				"Axis: 0, Value: 6.5\n" +
+				"    Axis: 0, Value: 3.5\n" +
+				"        Leaf: 1\n" +
+				"        Leaf: 5\n" +
+				"    Leaf: 10.25\n",
+		},
+	}
+
+	dfX := dataframe.New(series.New([]float64{1, 2, 3, 4, 5, 6, 7, 8}, series.Float, "Feature1"))
+	dfY := series.New([]float64{1, 1.5, 0.5, 5, 5.5, 4.5, 10, 10.5}, series.Float, "Target")
+	for _, test := range tests {
+		dtr := NewDecisionTreeRegressor()
+		test.set(dtr)
+		dtr.Fit(dfX, dfY)
+
+		if dtr.tree.String() != test.expected {
+			t.Errorf("%v Expected:\n%v\nGot:\n%v", test.name, test.expected, dtr.tree.String())
+		}
+	}
+}
+
+func TestDecisionTreeRegressor_Fit(t *testing.T) {
+	var expected strings.Builder
+	expected.WriteString("Leafs: 4, Depth: 3\n")
+	expected.WriteString("Axis: 0, Value: 6.5\n")
+	expected.WriteString("    Axis: 0, Value: 3.5\n")
+	expected.WriteString("        Leaf: 1\n")
+	expected.WriteString("        Leaf: 5\n")
+	expected.WriteString("    Axis: 0, Value: 7.5\n")
+	expected.WriteString("        Leaf: 10\n")
+	expected.WriteString("        Leaf: 10.5\n")
+
+	dtr := NewDecisionTreeRegressor()
+	dtr.SetMaxDepth(3)
+
+	dfX := dataframe.New(series.New([]float64{1, 2, 3, 4, 5, 6, 7, 8}, series.Float, "Feature1"))
+	dfY := series.New([]float64{1, 1.5, 0.5, 5, 5.5, 4.5, 10, 10.5}, series.Float, "Target")
+	dtr.Fit(dfX, dfY)
+
+	if dtr.tree.String() != expected.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected.String(), dtr.tree.String())
+	}
+}
+
+func TestDecisionTreeRegressor_FitMixedFeatures(t *testing.T) {
+	var expected strings.Builder
+	expected.WriteString("Leafs: 4, Depth: 3\n")
+	expected.WriteString("Axis: 4, Value: 0.45\n")
+	expected.WriteString("    Axis: 2, Categories: [green red]\n")
+	expected.WriteString("        Leaf: 1.3333333333333333\n")
+	expected.WriteString("        Leaf: 3\n")
+	expected.WriteString("    Axis: 2, Categories: [blue]\n")
+	expected.WriteString("        Leaf: 1.5\n")
+	expected.WriteString("        Leaf: 3\n")
+
+	dtr := NewDecisionTreeRegressor()
+	dtr.SetMaxDepth(3)
+
+	dfX := dataframe.New(
+		series.New([]int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3}, series.Int, "Count"),
+		series.New([]bool{true, false, true, true, false, false, true, false, true, false}, series.Boolean, "Flag"),
+		series.New([]string{"red", "green", "blue", "green", "red", "blue", "blue", "green", "red", "red"}, series.String, "Color"),
+		series.NewCategorical([]string{"s", "m", "l", "m", "s", "l", "xl", "m", "s", "l"}, []string{"s", "m", "l", "xl"}, "Size"),
+		series.New([]float64{0.2, 0.4, 0.9, 0.1, 0.8, 0.7, 0.3, 0.6, 0.5, 0.4}, series.Float, "Score"),
+	)
+	dfX.Columns()[4].Elem(2).Set(nil)
+	dfX.Columns()[4].Elem(5).Set(nil)
+	dfX.Columns()[4].Elem(9).Set(nil)
+	dtr.Fit(dfX, series.New([]float64{1, 1, 1, 2, 2, 2, 3, 3, 3, 4}, series.Float, "Target"))
+
+	if dtr.tree.String() != expected.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected.String(), dtr.tree.String())
+	}
+}
+
+func TestDecisionTreeRegressor_FitAdjacentValues(t *testing.T) {
+	expected := "{Target [0 1 2 3] float}"
+
+	dtr := NewDecisionTreeRegressor()
+
+	// The threshold between values without a midpoint between them is the smaller value, and the midpoints of the
+	// largest values are finite
+	dfX := dataframe.New(series.New([]float64{-math.MaxFloat64, 1, math.Nextafter(1, 2), math.MaxFloat64}, series.Float,
+		"Feature1"))
+	dfY := series.New([]float64{0, 1, 2, 3}, series.Float, "Target")
+	dtr.Fit(dfX, dfY)
+
+	predictions := dtr.Predict(dfX)
+	if predictions.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, predictions.String())
+	}
+}
+
+func TestDecisionTreeRegressor_Predict(t *testing.T) {
+	expected := "{Target [1 1 5 10.25] float}"
+
+	dtr := NewDecisionTreeRegressor()
+	dtr.SetCCPAlpha(0.1)
+
+	dfX := dataframe.New(series.New([]float64{1, 2, 3, 4, 5, 6, 7, 8}, series.Float, "Feature1"))
+	dfY := series.New([]float64{1, 1.5, 0.5, 5, 5.5, 4.5, 10, 10.5}, series.Float, "Target")
+	dtr.Fit(dfX, dfY)
+
+	predictions := dtr.Predict(dataframe.New(series.New([]float64{0.5, 3.5, 4, 7.9}, series.Float, "Feature1")))
+	if predictions.String() != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, predictions.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected Predict to panic, but it did not")
+		}
+	}()
+
+	NewDecisionTreeRegressor().Predict(dfX)
+}
+
+func TestDecisionTreeRegressor_CostComplexityPruningPath(t *testing.T) {
+	expectedAlphas := []float64{0, 0.015625, 0.015625, 0.015625, 0.046875, 0.046875, 3, 9.85546875}
+	expectedImpurities := []float64{0, 0.015625, 0.03125, 0.046875, 0.09375, 0.140625, 3.140625, 12.99609375}
+
+	dtr := NewDecisionTreeRegressor()
+	dfX := dataframe.New(series.New([]float64{1, 2, 3, 4, 5, 6, 7, 8}, series.Float, "Feature1"))
+	dfY := series.New([]float64{1, 1.5, 0.5, 5, 5.5, 4.5, 10, 10.5}, series.Float, "Target")
+
+	path := dtr.CostComplexityPruningPath(dfX, dfY)
+	alphas, impurities := path.Columns()[0].Floats(), path.Columns()[1].Floats()
+
+	if len(alphas) != len(expectedAlphas) {
+		t.Fatalf("Expected %v steps, got %v", len(expectedAlphas), len(alphas))
+	}
+
+	for i := range alphas {
+		if math.Abs(alphas[i]-expectedAlphas[i]) > 1e-9 || math.Abs(impurities[i]-expectedImpurities[i]) > 1e-9 {
+			t.Errorf("Expected step %v to be (%v, %v), got (%v, %v)", i, expectedAlphas[i], expectedImpurities[i],
+				alphas[i], impurities[i])
+		}
+	}
+}
+
+// absoluteCriterion measures the mean absolute deviation of the targets from their mean, which can be computed from
+// the statistics only for targets of 0 or 1, as 2p(1-p) for a fraction p of ones
+type absoluteCriterion struct {
+	total, left []float64
+}
+
+func (c *absoluteCriterion) Init(total []float64) {
+	c.total = total
+	c.left = make([]float64, len(total))
+}
+
+func (c *absoluteCriterion) Update(delta []float64) {
+	for k, d := range delta {
+		c.left[k] += d
+	}
+}
+
+func (c *absoluteCriterion) impurity(stats []float64) float64 {
+	p := stats[1] / stats[0]
+	return 2 * p * (1 - p)
+}
+
+func (c *absoluteCriterion) NodeImpurity() float64 {
+	return c.impurity(c.total)
+}
+
+func (c *absoluteCriterion) ChildrenImpurity() (float64, float64) {
+	right := []float64{c.total[0] - c.left[0], c.total[1] - c.left[1], c.total[2] - c.left[2]}
+	return c.impurity(c.left), c.impurity(right)
+}
+
+func TestDecisionTreeRegressor_SetCriterionFunction(t *testing.T) {
+	var expected strings.Builder
+	expected.WriteString("Leafs: 3, Depth: 3\n")
+	expected.WriteString("Axis: 0, Value: 5.5\n")
+	expected.WriteString("    Axis: 0, Value: 2.5\n")
+	expected.WriteString("        Leaf: 0\n")
+	expected.WriteString("        Leaf: 1\n")
+	expected.WriteString("    Leaf: 0\n")
+
+	dtr := NewDecisionTreeRegressor()
+	dtr.SetCriterionFunction(&absoluteCriterion{})
+	if dtr.criterionString != "custom" {
+		t.Errorf("Expected criterion to be custom, got %v", dtr.criterionString)
+	}
+
+	dfX := dataframe.New(series.New([]float64{1, 2, 3, 4, 5, 6, 7, 8}, series.Float, "Feature1"))
+	dtr.Fit(dfX, series.New([]float64{0, 0, 1, 1, 1, 0, 0, 0}, series.Float, "Target"))
+
+	if dtr.tree.String() != expected.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected.String(), dtr.tree.String())
+	}
+	if dtr.tree.Impurity != 0.46875 {
+		t.Errorf("Expected root impurity 0.46875, got %v", dtr.tree.Impurity)
+	}
+}
+
+func TestDecisionTreeRegressor_FitWeighted(t *testing.T) {
+	// Integer weights give the same tree as repeating each sample by its weight
+	weights := []float64{2, 1, 1, 3, 1, 1, 1, 2}
+	dfX := dataframe.New(series.New([]float64{1, 2, 3, 4, 5, 6, 7, 8}, series.Float, "Feature1"))
+	dfY := series.New([]float64{1, 1.5, 0.5, 5, 5.5, 4.5, 10, 10.5}, series.Float, "Target")
+
+	weighted := NewDecisionTreeRegressor()
+	weighted.SetMaxDepth(3)
+	weighted.FitWeighted(dfX, dfY, series.New(weights, series.Float, "Weight"))
+
+	rows := duplicateRows(weights)
+	duplicated := NewDecisionTreeRegressor()
+	duplicated.SetMaxDepth(3)
+	duplicated.Fit(dfX.Take(rows...), dfY.Take(rows...))
+
+	if weighted.tree.String() != duplicated.tree.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", duplicated.tree.String(), weighted.tree.String())
+	}
+
+	// A weighted leaf predicts the weighted mean of its targets
+	single := NewDecisionTreeRegressor()
+	single.SetMaxDepth(1)
+	single.FitWeighted(dfX, dfY, series.New([]float64{1, 0, 0, 0, 0, 0, 0, 3}, series.Float, "Weight"))
+
+	if single.tree.Output != 8.125 {
+		t.Errorf("Expected weighted mean 8.125, got %v", single.tree.Output)
+	}
+}
+
+func TestDecisionTreeRegressor_Introspection(t *testing.T) {
+	dtr := NewDecisionTreeRegressor()
+	dtr.SetMaxDepth(3)
+
+	dfX := dataframe.New(series.New([]float64{1, 2, 3, 4, 5, 6, 7, 8}, series.Float, "Feature1"))
+	dfY := series.New([]float64{1, 1.5, 0.5, 5, 5.5, 4.5, 10, 10.5}, series.Float, "Target")
+	dtr.Fit(dfX, dfY)
+
+	if importances := dtr.FeatureImportances(); len(importances) != 1 || importances["Feature1"] != 1 {
+		t.Errorf("Expected Feature1 to have all the importance, got %v", importances)
+	}
+
+	if depth, leaves := dtr.GetDepth(), dtr.GetNLeaves(); depth != 3 || leaves != 4 {
+		t.Errorf("Expected depth 3 and 4 leaves, got %v and %v", depth, leaves)
+	}
+
+	expectedLeaves := "{Leaf [2 2 2 3 3 3 5 6] int}"
+	if leaves := dtr.Apply(dfX); leaves.String() != expectedLeaves {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expectedLeaves, leaves.String())
+	}
+
+	paths := dtr.DecisionPath(dfX)
+	if len(paths) != 8 || len(paths[7]) != 3 || paths[7][0] != 0 || paths[7][1] != 4 || paths[7][2] != 6 {
+		t.Errorf("Expected the last sample to pass through nodes [0 4 6], got %v", paths)
+	}
+}
+
+func TestDecisionTreeRegressor_Export(t *testing.T) {
+	var expectedText strings.Builder
+	expectedText.WriteString("if Feature1 <= 6.5 then\n")
+	expectedText.WriteString("    value = 3 (samples = 6)\n")
+	expectedText.WriteString("else\n")
+	expectedText.WriteString("    value = 10.25 (samples = 2)\n")
+
+	dtr := NewDecisionTreeRegressor()
+	dtr.SetMaxDepth(2)
+
+	dfX := dataframe.New(series.New([]float64{1, 2, 3, 4, 5, 6, 7, 8}, series.Float, "Feature1"))
+	dfY := series.New([]float64{1, 1.5, 0.5, 5, 5.5, 4.5, 10, 10.5}, series.Float, "Target")
+	dtr.Fit(dfX, dfY)
+
+	if text := dtr.ExportText(); text != expectedText.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expectedText.String(), text)
+	}
+
+	// Nodes are shaded from white for the smallest prediction to orange for the largest
+	dot := dtr.ExportGraphviz()
+	for _, expected := range []string{
+		"0 [label=\"Feature1 <= 6.5\\nmse = 12.99609375\\nsamples = 8\\nvalue = 4.8125\", fillcolor=\"#f9e0ce\"] ;",
+		"1 [label=\"mse = 4.166666666666666\\nsamples = 6\\nvalue = 3\", fillcolor=\"#ffffff\"] ;",
+		"2 [label=\"mse = 0.0625\\nsamples = 2\\nvalue = 10.25\", fillcolor=\"#e58139\"] ;",
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("Expected:\n%v\nin:\n%v", expected, dot)
+		}
+	}
+}
+
+func TestDecisionTreeRegressor_IsClassifier(t *testing.T) {
+	dtr := NewDecisionTreeRegressor()
+
+	if dtr.IsClassifier() {
+		t.Errorf("Expected IsClassifier to return false, got true")
+	}
+}
+
+func TestDecisionTreeRegressor_IsRegressor(t *testing.T) {
+	dtr := NewDecisionTreeRegressor()
+
+	if !dtr.IsRegressor() {
+		t.Errorf("Expected IsRegressor to return true, got false")
+	}
+}
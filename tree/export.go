@@ -0,0 +1,200 @@
+package tree
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// exporter formats a fit DecisionTree with the names of the features of a model, and the classes of a classifier,
+// which are nil for a regressor
+type exporter struct {
+	tree      *DecisionTree
+	features  []string
+	classes   []int
+	criterion string
+}
+
+// condition returns the condition of a split node, which is true for samples sent to the left child, which are those at
+// or below the threshold of a numeric feature. NA values are only included when their direction was learnt from fit
+// samples with NA values
+func (e exporter) condition(node *DecisionTree) string {
+	feature := e.features[node.Axis]
+
+	condition := fmt.Sprintf("%v <= %v", feature, node.Value)
+	if node.Categories != nil {
+		condition = fmt.Sprintf("%v in %v", feature, node.Categories)
+	}
+	if node.MissingSeen && node.MissingLeft {
+		condition += fmt.Sprintf(" or %v is NA", feature)
+	}
+	return condition
+}
+
+// prediction returns the prediction of a node
+func (e exporter) prediction(node *DecisionTree) string {
+	if e.classes == nil {
+		return fmt.Sprintf("value = %v", node.Output)
+	}
+	return fmt.Sprintf("class = %v", node.Label)
+}
+
+// text returns the rules of the tree as nested if then else statements, with the prediction and number of samples of
+// each leaf
+func (e exporter) text() string {
+	var s strings.Builder
+
+	var visit func(node *DecisionTree, depth int)
+	visit = func(node *DecisionTree, depth int) {
+		indent := strings.Repeat("    ", depth)
+		if node.Leaf {
+			s.WriteString(fmt.Sprintf("%v%v (samples = %v)\n", indent, e.prediction(node), node.Samples))
+			return
+		}
+
+		s.WriteString(fmt.Sprintf("%vif %v then\n", indent, e.condition(node)))
+		visit(node.Left, depth+1)
+		s.WriteString(fmt.Sprintf("%velse\n", indent))
+		visit(node.Right, depth+1)
+	}
+	visit(e.tree, 0)
+
+	return s.String()
+}
+
+// graphviz returns the tree in the DOT language of Graphviz. Each node is labelled with its condition, impurity,
+// number of samples and prediction, and filled with the color of its most common class, or a shade of orange by its
+// prediction for regression, which is paler the less certain the node is
+func (e exporter) graphviz() string {
+	var s strings.Builder
+	s.WriteString("digraph Tree {\n")
+	s.WriteString("node [shape=box, style=\"filled, rounded\", fontname=\"helvetica\"] ;\n")
+	s.WriteString("edge [fontname=\"helvetica\"] ;\n")
+
+	// Regression nodes are shaded between the smallest and largest prediction
+	low, high := math.Inf(1), math.Inf(-1)
+	var bounds func(node *DecisionTree)
+	bounds = func(node *DecisionTree) {
+		low, high = math.Min(low, node.Output), math.Max(high, node.Output)
+		if !node.Leaf {
+			bounds(node.Left)
+			bounds(node.Right)
+		}
+	}
+	bounds(e.tree)
+
+	var visit func(node *DecisionTree)
+	visit = func(node *DecisionTree) {
+		var label []string
+		if !node.Leaf {
+			label = append(label, e.condition(node))
+		}
+		label = append(label, fmt.Sprintf("%v = %v", e.criterion, node.Impurity))
+		label = append(label, fmt.Sprintf("samples = %v", node.Samples))
+		if e.classes != nil {
+			label = append(label, fmt.Sprintf("value = %v", node.Counts))
+		}
+		label = append(label, e.prediction(node))
+
+		var color string
+		if e.classes == nil {
+			alpha := 1.0
+			if high > low {
+				alpha = (node.Output - low) / (high - low)
+			}
+			color = blend(classColor(0, 1), alpha)
+		} else {
+			color = e.classColor(node)
+		}
+
+		s.WriteString(fmt.Sprintf("%v [label=\"%v\", fillcolor=\"%v\"] ;\n", node.ID,
+			escape(strings.Join(label, "\n")), color))
+
+		if node.Leaf {
+			return
+		}
+
+		visit(node.Left)
+		s.WriteString(fmt.Sprintf("%v -> %v [labeldistance=2.5, labelangle=45, headlabel=\"True\"] ;\n", node.ID,
+			node.Left.ID))
+		visit(node.Right)
+		s.WriteString(fmt.Sprintf("%v -> %v [labeldistance=2.5, labelangle=-45, headlabel=\"False\"] ;\n", node.ID,
+			node.Right.ID))
+	}
+	visit(e.tree)
+
+	s.WriteString("}\n")
+	return s.String()
+}
+
+// classColor returns the color of the most common class of a classification node, blended with white by the margin
+// of the most common class over the next most common class
+func (e exporter) classColor(node *DecisionTree) string {
+	total := 0.0
+	majority := 0
+	for k, c := range node.Counts {
+		total += c
+		if c > node.Counts[majority] {
+			majority = k
+		}
+	}
+
+	proportions := make([]float64, len(node.Counts))
+	for k, c := range node.Counts {
+		proportions[k] = c / total
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(proportions)))
+
+	alpha := 1.0
+	if len(proportions) > 1 && proportions[1] < 1 {
+		alpha = (proportions[0] - proportions[1]) / (1 - proportions[1])
+	}
+	return blend(classColor(majority, len(node.Counts)), alpha)
+}
+
+// classColor returns the color of class k of n classes, with hues evenly spaced around the color wheel
+func classColor(k, n int) [3]float64 {
+	// Convert the hue from HSV with a saturation of 0.75 and a value of 0.9 to RGB
+	h := math.Mod(25+360*float64(k)/float64(n), 360) / 60
+	c := 0.9 * 0.75
+	x := c * (1 - math.Abs(math.Mod(h, 2)-1))
+	m := 0.9 - c
+
+	var rgb [3]float64
+	switch int(h) {
+	case 0:
+		rgb = [3]float64{c, x, 0}
+	case 1:
+		rgb = [3]float64{x, c, 0}
+	case 2:
+		rgb = [3]float64{0, c, x}
+	case 3:
+		rgb = [3]float64{0, x, c}
+	case 4:
+		rgb = [3]float64{x, 0, c}
+	default:
+		rgb = [3]float64{c, 0, x}
+	}
+
+	for i := range rgb {
+		rgb[i] += m
+	}
+	return rgb
+}
+
+// blend returns the hex code of a color blended with white, from white at an alpha of 0 to the color at 1
+func blend(rgb [3]float64, alpha float64) string {
+	hex := "#"
+	for _, v := range rgb {
+		hex += fmt.Sprintf("%02x", int(math.Round(255*(alpha*v+1-alpha))))
+	}
+	return hex
+}
+
+// escape escapes a label for a quoted string in the DOT language
+func escape(label string) string {
+	label = strings.ReplaceAll(label, "\\", "\\\\")
+	label = strings.ReplaceAll(label, "\"", "\\\"")
+	return strings.ReplaceAll(label, "\n", "\\n")
+}
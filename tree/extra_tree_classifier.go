@@ -0,0 +1,24 @@
+package tree
+
+import (
+	"github.com/chriso345/golab"
+)
+
+// ExtraTreeClassifier is an extremely randomized tree classifier. Rather than searching every split, each node draws
+// one random split of each candidate feature, at a threshold drawn uniformly between the smallest and largest values
+// of a numeric feature or into a random group of the categories of a categorical feature, and keeps the best of them.
+// It shares the settings, predictions, introspection and export of the DecisionTreeClassifier, and SetRandomState
+// makes its fits reproducible
+type ExtraTreeClassifier struct {
+	DecisionTreeClassifier
+}
+
+// force implementation of ProbabilisticClassifier interface
+var _ golab.ProbabilisticClassifier = (*ExtraTreeClassifier)(nil)
+
+// NewExtraTreeClassifier creates a new ExtraTreeClassifier with default values
+func NewExtraTreeClassifier() *ExtraTreeClassifier {
+	etc := &ExtraTreeClassifier{DecisionTreeClassifier: *NewDecisionTreeClassifier()}
+	etc.randomSplits = true
+	return etc
+}
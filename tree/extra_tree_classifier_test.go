@@ -0,0 +1,106 @@
+package tree
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe"
+	"github.com/chriso345/golab/dataframe/series"
+	"testing"
+)
+
+func TestNewExtraTreeClassifier(t *testing.T) {
+	etc := NewExtraTreeClassifier()
+
+	if !etc.randomSplits {
+		t.Errorf("Expected randomSplits to be set")
+	}
+
+	if etc.criterionString != "gini" || etc.maxDepth != -1 || etc.maxFeatures != -1 || etc.randomState != -1 {
+		t.Errorf("Expected the defaults of a DecisionTreeClassifier")
+	}
+}
+
+func TestExtraTreeClassifier_Fit(t *testing.T) {
+	dfX := dataframe.New(
+		series.New([]float64{0.9074, 0.9529, 0.5635, 0.9567, 0.8162, 0.3279, 0.0179, 0.4246, 0.4770, 0.3394, 0.0788, 0.4853, 0.4786, 0.2427, 0.4001, 0.8530, 0.5159, 0.6385, 0.5231, 0.5486}, series.Float, "Feature1"),
+		series.New([]float64{0.5488, 0.6392, 0.7734, 0.9788, 0.9824, 0.3789, 0.3716, 0.1961, 0.3277, 0.0856, 0.5709, 0.7109, 0.9579, 0.8961, 0.9797, 0.4117, 0.3474, 0.1585, 0.4751, 0.0172}, series.Float, "Feature2"),
+	)
+	dfY := series.New([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, series.Int, "Target")
+
+	etc := NewExtraTreeClassifier()
+	etc.SetRandomState(42)
+	etc.Fit(dfX, dfY)
+
+	// A fully grown tree separates the distinct samples
+	expected := fmt.Sprint(dfY)
+	if got := fmt.Sprint(etc.Predict(dfX)); got != expected {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	// Thresholds are drawn between the values of the samples rather than at them
+	dtc := NewDecisionTreeClassifier()
+	dtc.Fit(dfX, dfY)
+	if etc.tree.String() == dtc.tree.String() {
+		t.Errorf("Expected random splits to differ from the best splits")
+	}
+
+	// The same seed gives the same tree, and another seed gives another tree
+	same := NewExtraTreeClassifier()
+	same.SetRandomState(42)
+	same.Fit(dfX, dfY)
+	if same.tree.String() != etc.tree.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", etc.tree.String(), same.tree.String())
+	}
+
+	other := NewExtraTreeClassifier()
+	other.SetRandomState(7)
+	other.Fit(dfX, dfY)
+	if other.tree.String() == etc.tree.String() {
+		t.Errorf("Expected different seeds to give different trees")
+	}
+}
+
+func TestExtraTreeClassifier_FitMixedFeatures(t *testing.T) {
+	dfX := dataframe.New(
+		series.New([]int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3}, series.Int, "Count"),
+		series.New([]bool{true, false, true, true, false, false, true, false, true, false}, series.Boolean, "Flag"),
+		series.New([]string{"red", "green", "blue", "green", "red", "blue", "blue", "green", "red", "red"}, series.String, "Color"),
+		series.NewCategorical([]string{"s", "m", "l", "m", "s", "l", "xl", "m", "s", "l"}, []string{"s", "m", "l", "xl"}, "Size"),
+		series.New([]float64{0.2, 0.4, 0.9, 0.1, 0.8, 0.7, 0.3, 0.6, 0.5, 0.4}, series.Float, "Score"),
+	)
+	dfX.Columns()[4].Elem(2).Set(nil)
+	dfX.Columns()[4].Elem(5).Set(nil)
+	dfX.Columns()[4].Elem(9).Set(nil)
+	dfY := series.New([]int{0, 1, 2, 1, 0, 2, 2, 1, 0, 2}, series.Int, "Target")
+
+	etc := NewExtraTreeClassifier()
+	etc.SetRandomState(3)
+	etc.SetMaxDepth(3)
+	etc.Fit(dfX, dfY)
+
+	same := NewExtraTreeClassifier()
+	same.SetRandomState(3)
+	same.SetMaxDepth(3)
+	same.Fit(dfX, dfY)
+
+	if same.tree.String() != etc.tree.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", etc.tree.String(), same.tree.String())
+	}
+
+	if etc.GetDepth() > 3 || etc.GetNLeaves() < 2 {
+		t.Errorf("Expected a split tree of depth at most 3, got depth %v with %v leaves", etc.GetDepth(),
+			etc.GetNLeaves())
+	}
+
+	probabilities := etc.PredictProbability(dfX)
+	if _, columns := probabilities.Shape(); columns != 3 {
+		t.Errorf("Expected probabilities of 3 classes, got %v", columns)
+	}
+}
+
+func TestExtraTreeClassifier_IsClassifier(t *testing.T) {
+	etc := NewExtraTreeClassifier()
+
+	if !etc.IsClassifier() {
+		t.Errorf("Expected IsClassifier to return true, got false")
+	}
+}
@@ -0,0 +1,24 @@
+package tree
+
+import (
+	"github.com/chriso345/golab"
+)
+
+// ExtraTreeRegressor is an extremely randomized tree regressor. Rather than searching every split, each node draws one
+// random split of each candidate feature, at a threshold drawn uniformly between the smallest and largest values of a
+// numeric feature or into a random group of the categories of a categorical feature, and keeps the best of them. It
+// shares the settings, predictions, introspection and export of the DecisionTreeRegressor, and SetRandomState makes
+// its fits reproducible
+type ExtraTreeRegressor struct {
+	DecisionTreeRegressor
+}
+
+// force implementation of Model interface
+var _ golab.Model = (*ExtraTreeRegressor)(nil)
+
+// NewExtraTreeRegressor creates a new ExtraTreeRegressor with default values
+func NewExtraTreeRegressor() *ExtraTreeRegressor {
+	etr := &ExtraTreeRegressor{DecisionTreeRegressor: *NewDecisionTreeRegressor()}
+	etr.randomSplits = true
+	return etr
+}
@@ -0,0 +1,89 @@
+package tree
+
+import (
+	"fmt"
+	"github.com/chriso345/golab/dataframe"
+	"github.com/chriso345/golab/dataframe/series"
+	"testing"
+)
+
+func TestNewExtraTreeRegressor(t *testing.T) {
+	etr := NewExtraTreeRegressor()
+
+	if !etr.randomSplits {
+		t.Errorf("Expected randomSplits to be set")
+	}
+
+	if etr.criterionString != "mse" || etr.maxDepth != -1 || etr.randomState != -1 {
+		t.Errorf("Expected the defaults of a DecisionTreeRegressor")
+	}
+}
+
+func TestExtraTreeRegressor_SetRandomState(t *testing.T) {
+	etr := NewExtraTreeRegressor()
+	etr.SetRandomState(1)
+
+	if etr.randomState != 1 {
+		t.Errorf("Expected randomState to be 1, got %v", etr.randomState)
+	}
+
+	t.Run("negative", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Expected panic for negative randomState")
+			}
+		}()
+
+		etr.SetRandomState(-2)
+	})
+
+	t.Run("after fit", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Expected panic when setting randomState after fit")
+			}
+		}()
+
+		dfX := dataframe.New(series.New([]float64{1, 2, 3, 4, 5, 6, 7, 8}, series.Float, "Feature1"))
+		dfY := series.New([]float64{1, 1.5, 0.5, 5, 5.5, 4.5, 10, 10.5}, series.Float, "Target")
+		etr.Fit(dfX, dfY)
+		etr.SetRandomState(2)
+	})
+}
+
+func TestExtraTreeRegressor_Fit(t *testing.T) {
+	dfX := dataframe.New(series.New([]float64{1, 2, 3, 4, 5, 6, 7, 8}, series.Float, "Feature1"))
+	dfY := series.New([]float64{1, 1.5, 0.5, 5, 5.5, 4.5, 10, 10.5}, series.Float, "Target")
+
+	etr := NewExtraTreeRegressor()
+	etr.SetRandomState(42)
+	etr.Fit(dfX, dfY)
+
+	// A fully grown tree predicts the target of each distinct sample
+	expected := fmt.Sprint(dfY)
+	if got := fmt.Sprint(etr.Predict(dfX)); got != expected {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	same := NewExtraTreeRegressor()
+	same.SetRandomState(42)
+	same.Fit(dfX, dfY)
+	if same.tree.String() != etr.tree.String() {
+		t.Errorf("Expected:\n%v\nGot:\n%v", etr.tree.String(), same.tree.String())
+	}
+
+	// Thresholds are drawn between the values of the samples rather than at them
+	for _, node := range []*DecisionTree{etr.tree, etr.tree.Left, etr.tree.Right} {
+		if !node.Leaf && node.Value == float64(int(node.Value)) {
+			t.Errorf("Expected a threshold between integer values, got %v", node.Value)
+		}
+	}
+}
+
+func TestExtraTreeRegressor_IsRegressor(t *testing.T) {
+	etr := NewExtraTreeRegressor()
+
+	if !etr.IsRegressor() {
+		t.Errorf("Expected IsRegressor to return true, got false")
+	}
+}
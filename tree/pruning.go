@@ -0,0 +1,86 @@
+package tree
+
+import "math"
+
+// Minimal cost-complexity pruning measures a tree T by $R_\alpha(T) = R(T) + \alpha|T|$, where $R(T)$ is the total
+// impurity of the leaves of T weighted by the fraction of the weight of the samples reaching each leaf, and $|T|$ is the number of
+// leaves. Collapsing the branch below a node t into a leaf increases $R(T)$ by $R(t) - R(T_t)$ and removes
+// $|T_t| - 1$ leaves, so the branch is worth keeping only while alpha is below its effective alpha
+// $\alpha_{eff}(t) = \frac{R(t) - R(T_t)}{|T_t| - 1}$. The weakest link, the node with the smallest effective alpha,
+// is collapsed repeatedly.
+
+// weightedImpurity returns the impurity of the node weighted by the fraction of the total weight of the samples
+// reaching it
+func (dt *DecisionTree) weightedImpurity(total float64) float64 {
+	return dt.WeightedSamples / total * dt.Impurity
+}
+
+// leafImpurity returns the total weighted impurity of the leaves of the branch, and the number of leaves
+func (dt *DecisionTree) leafImpurity(total float64) (float64, int) {
+	if dt.Leaf {
+		return dt.weightedImpurity(total), 1
+	}
+
+	leftImpurity, leftLeaves := dt.Left.leafImpurity(total)
+	rightImpurity, rightLeaves := dt.Right.leafImpurity(total)
+	return leftImpurity + rightImpurity, leftLeaves + rightLeaves
+}
+
+// weakestLink returns the split node of the branch with the smallest effective alpha, preferring the first node in
+// depth first order on ties, and its effective alpha. The node is nil if the branch is a leaf
+func (dt *DecisionTree) weakestLink(total float64) (*DecisionTree, float64) {
+	if dt.Leaf {
+		return nil, math.Inf(1)
+	}
+
+	impurity, leaves := dt.leafImpurity(total)
+	weakest, alpha := dt, (dt.weightedImpurity(total)-impurity)/float64(leaves-1)
+
+	for _, child := range []*DecisionTree{dt.Left, dt.Right} {
+		if node, childAlpha := child.weakestLink(total); childAlpha < alpha {
+			weakest, alpha = node, childAlpha
+		}
+	}
+	return weakest, alpha
+}
+
+// collapse turns the split node into a leaf, which keeps the prediction of the node
+func (dt *DecisionTree) collapse() {
+	dt.Leaf = true
+	dt.Axis = 0
+	dt.Value = 0
+	dt.Categories = nil
+	dt.MissingLeft = false
+	dt.MissingSeen = false
+	dt.Left = nil
+	dt.Right = nil
+}
+
+// prune collapses the weakest link of the tree while its effective alpha is at most ccpAlpha
+func prune(root *DecisionTree, ccpAlpha float64) {
+	for {
+		node, alpha := root.weakestLink(root.WeightedSamples)
+		if node == nil || alpha > ccpAlpha {
+			return
+		}
+		node.collapse()
+	}
+}
+
+// pruningPath collapses the weakest link of the tree until only the root remains, returning the effective alpha of
+// each step and the total weighted impurity of the leaves after it. The first step is the unpruned tree with an alpha
+// of 0
+func pruningPath(root *DecisionTree) ([]float64, []float64) {
+	impurity, _ := root.leafImpurity(root.WeightedSamples)
+	alphas, impurities := []float64{0}, []float64{impurity}
+
+	for !root.Leaf {
+		node, alpha := root.weakestLink(root.WeightedSamples)
+		node.collapse()
+
+		impurity, _ = root.leafImpurity(root.WeightedSamples)
+		alphas = append(alphas, alpha)
+		impurities = append(impurities, impurity)
+	}
+	return alphas, impurities
+}
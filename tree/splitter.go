@@ -0,0 +1,370 @@
+package tree
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// feature holds the value of a feature for each fit sample, which is NaN for NA values. The values of a categorical
+// feature are the positions of the categories of the samples in categories, which is nil for a numeric feature
+type feature struct {
+	values     []float64
+	categories []string
+}
+
+// samples holds the samples reaching a node, once for each feature, in ascending order of that feature with NA values
+// last. Sorting every feature once before fitting and partitioning the sorted samples at each split keeps them
+// sorted, so the split search at each node is a single pass over each feature
+type samples [][]int
+
+// presort returns the positions of every sample sorted by each feature
+func presort(features []feature) samples {
+	sorted := make(samples, len(features))
+	for axis, f := range features {
+		values := f.values
+		sorted[axis] = make([]int, len(values))
+		for i := range sorted[axis] {
+			sorted[axis][i] = i
+		}
+		sort.SliceStable(sorted[axis], func(i, j int) bool {
+			a, b := values[sorted[axis][i]], values[sorted[axis][j]]
+			return a < b || (!math.IsNaN(a) && math.IsNaN(b))
+		})
+	}
+	return sorted
+}
+
+// len returns the number of samples reaching the node
+func (s samples) len() int {
+	return len(s[0])
+}
+
+// partition splits the samples into those for which left is true and the rest, keeping each feature sorted
+func (s samples) partition(left []bool) (samples, samples) {
+	leftSamples := make(samples, len(s))
+	rightSamples := make(samples, len(s))
+	for axis, sorted := range s {
+		leftSamples[axis] = make([]int, 0, len(sorted))
+		rightSamples[axis] = make([]int, 0, len(sorted))
+		for _, i := range sorted {
+			if left[i] {
+				leftSamples[axis] = append(leftSamples[axis], i)
+			} else {
+				rightSamples[axis] = append(rightSamples[axis], i)
+			}
+		}
+	}
+	return leftSamples, rightSamples
+}
+
+// split is a candidate split of a node on the feature at axis. Samples with a numeric value at most value, or with a
+// categorical value in categories, go left, and samples with NA values go left if missingLeft is set
+type split struct {
+	axis        int
+	value       float64
+	categories  []bool
+	missingLeft bool
+	impurity    float64
+}
+
+// goesLeft returns true if a sample with the value v of the feature of the split goes to the left child
+func (sp split) goesLeft(v float64) bool {
+	if math.IsNaN(v) {
+		return sp.missingLeft
+	}
+	if sp.categories != nil {
+		return sp.categories[int(v)]
+	}
+	return v <= sp.value
+}
+
+// splitter searches for the split of the samples reaching a node with the lowest impurity of the children, weighted by
+// the fraction of the total weight of the samples sent to each child
+type splitter struct {
+	target         target
+	criterion      Criterion
+	minSamplesLeaf int
+	// random draws a single random split of each feature when set, instead of searching every split
+	random *rand.Rand
+
+	n      int
+	weight float64
+	total  []float64
+	delta  []float64
+	best   split
+}
+
+// bestSplit finds the split of the samples on one of the axes with the lowest weighted impurity of the children. Only
+// splits which leave at least minSamplesLeaf samples in each child and have an impurity strictly below the impurity
+// of the node are considered, and the first is kept on ties. The axis of the split is -1 if no split improves on the
+// node. When random is set, a single random split of each feature is considered instead of every split
+func bestSplit(features []feature, t target, s samples, criterion Criterion, axes []int, minSamplesLeaf int,
+	random *rand.Rand) split {
+	total := stats(t, s[0])
+	criterion.Init(total)
+	sp := splitter{
+		target:         t,
+		criterion:      criterion,
+		minSamplesLeaf: minSamplesLeaf,
+		random:         random,
+		n:              s.len(),
+		weight:         t.weight(total),
+		total:          total,
+		delta:          make([]float64, len(total)),
+		best:           split{axis: -1, impurity: criterion.NodeImpurity()},
+	}
+
+	for _, axis := range axes {
+		sp.criterion.Init(sp.total)
+		switch {
+		case random != nil && features[axis].categories == nil:
+			sp.randomNumeric(axis, features[axis], s[axis])
+		case random != nil:
+			sp.randomCategorical(axis, features[axis], s[axis])
+		case features[axis].categories == nil:
+			sp.numeric(axis, features[axis], s[axis])
+		default:
+			sp.categorical(axis, features[axis], s[axis])
+		}
+	}
+	return sp.best
+}
+
+// move moves sample i from the right child to the left child of the criterion
+func (sp *splitter) move(i int) {
+	sp.target.add(sp.delta, i, 1)
+	sp.criterion.Update(sp.delta)
+	sp.target.add(sp.delta, i, -1)
+}
+
+// consider keeps a candidate split if it improves on the best split so far, where left holds the statistics of the
+// targets of the nLeft samples sent to the left child by the criterion
+func (sp *splitter) consider(candidate split, left []float64, nLeft int) {
+	if nLeft < sp.minSamplesLeaf || sp.n-nLeft < sp.minSamplesLeaf {
+		return
+	}
+
+	weight := sp.target.weight(left) / sp.weight
+	leftImpurity, rightImpurity := sp.criterion.ChildrenImpurity()
+	impurity := weight*leftImpurity + (1-weight)*rightImpurity
+	if impurity < sp.best.impurity {
+		if candidate.categories != nil {
+			candidate.categories = append([]bool{}, candidate.categories...)
+		}
+		candidate.impurity = impurity
+		sp.best = candidate
+	}
+}
+
+// considerMissing keeps a candidate split which also sends the samples with NA values, with the statistics missing,
+// to the left child, moving them there in the criterion for the candidate only
+func (sp *splitter) considerMissing(candidate split, left, missing []float64, nLeft int) {
+	negated := make([]float64, len(missing))
+	for k := range missing {
+		negated[k] = -missing[k]
+	}
+
+	candidate.missingLeft = true
+	sp.criterion.Update(missing)
+	sp.consider(candidate, withMissing(left, missing), nLeft)
+	sp.criterion.Update(negated)
+}
+
+// withMissing returns the sum of the statistics of the targets of the left child and of the samples with NA values
+func withMissing(left, missing []float64) []float64 {
+	sum := make([]float64, len(left))
+	for k := range sum {
+		sum[k] = left[k] + missing[k]
+	}
+	return sum
+}
+
+// numeric searches the splits of a numeric feature between distinct values, by moving samples from the right child to
+// the left child in ascending order of the feature and updating the statistics of the targets of the left child. The
+// threshold of a split is the midpoint of the adjacent distinct values, or the smaller value when they are too close
+// for a midpoint between them. When some samples have NA values, they are tried in each child, and alone in the right
+// child
+func (sp *splitter) numeric(axis int, f feature, sorted []int) {
+	m := len(sorted)
+	for m > 0 && math.IsNaN(f.values[sorted[m-1]]) {
+		m--
+	}
+	missing := stats(sp.target, sorted[m:])
+
+	left := make([]float64, len(sp.total))
+	for i := 1; i <= m; i++ {
+		sp.target.add(left, sorted[i-1], 1)
+		sp.move(sorted[i-1])
+		if i == m {
+			if m < sp.n {
+				sp.consider(split{axis: axis, value: math.Inf(1)}, left, m)
+			}
+			break
+		}
+		if f.values[sorted[i-1]] == f.values[sorted[i]] {
+			continue
+		}
+
+		value := threshold(f.values[sorted[i-1]], f.values[sorted[i]])
+		sp.consider(split{axis: axis, value: value}, left, i)
+		if m < sp.n {
+			sp.considerMissing(split{axis: axis, value: value}, left, missing, i+sp.n-m)
+		}
+	}
+}
+
+// threshold returns the midpoint of the adjacent distinct values a < b, or a if the midpoint rounds to b. Halving each
+// value first keeps the midpoint of the largest values finite
+func threshold(a, b float64) float64 {
+	value := a/2 + b/2
+	if value >= b {
+		return a
+	}
+	return value
+}
+
+// categorical searches the splits of a categorical feature into two groups of categories. The categories are ordered
+// by the score of their targets and split like the values of a numeric feature, which finds the best grouping for
+// regression and binary classification. When some samples have NA values, they are tried in each child, and alone in
+// the right child
+func (sp *splitter) categorical(axis int, f feature, samples []int) {
+	categoryStats := make([][]float64, len(f.categories))
+	categorySamples := make([]int, len(f.categories))
+	var missingSamples []int
+	for _, i := range samples {
+		if math.IsNaN(f.values[i]) {
+			missingSamples = append(missingSamples, i)
+			continue
+		}
+
+		c := int(f.values[i])
+		if categoryStats[c] == nil {
+			categoryStats[c] = make([]float64, len(sp.total))
+		}
+		sp.target.add(categoryStats[c], i, 1)
+		categorySamples[c]++
+	}
+	missing := stats(sp.target, missingSamples)
+
+	var present []int
+	for c, n := range categorySamples {
+		if n > 0 {
+			present = append(present, c)
+		}
+	}
+	sort.SliceStable(present, func(i, j int) bool {
+		return sp.target.score(categoryStats[present[i]], sp.total) < sp.target.score(categoryStats[present[j]], sp.total)
+	})
+
+	left := make([]float64, len(sp.total))
+	categories := make([]bool, len(f.categories))
+	nLeft := 0
+	for j, c := range present {
+		for k := range left {
+			left[k] += categoryStats[c][k]
+		}
+		sp.criterion.Update(categoryStats[c])
+		categories[c] = true
+		nLeft += categorySamples[c]
+
+		if j == len(present)-1 {
+			if len(missingSamples) > 0 {
+				sp.consider(split{axis: axis, categories: categories}, left, nLeft)
+			}
+			break
+		}
+
+		sp.consider(split{axis: axis, categories: categories}, left, nLeft)
+		if len(missingSamples) > 0 {
+			sp.considerMissing(split{axis: axis, categories: categories}, left, missing, nLeft+len(missingSamples))
+		}
+	}
+}
+
+// randomNumeric considers a split of a numeric feature at a threshold drawn uniformly between the smallest and largest
+// values of the samples, sending the samples with NA values to each child in turn
+func (sp *splitter) randomNumeric(axis int, f feature, sorted []int) {
+	m := len(sorted)
+	for m > 0 && math.IsNaN(f.values[sorted[m-1]]) {
+		m--
+	}
+	if m == 0 {
+		return
+	}
+
+	low, high := f.values[sorted[0]], f.values[sorted[m-1]]
+	if low == high {
+		return
+	}
+
+	// Samples go left at or below the threshold, so a threshold which rounds up to the largest value is moved to the
+	// smallest value to keep both children non-empty
+	value := low + sp.random.Float64()*(high-low)
+	if value >= high {
+		value = low
+	}
+
+	left := make([]float64, len(sp.total))
+	nLeft := 0
+	for _, i := range sorted[:m] {
+		if f.values[i] > value {
+			break
+		}
+		sp.target.add(left, i, 1)
+		sp.move(i)
+		nLeft++
+	}
+
+	sp.consider(split{axis: axis, value: value}, left, nLeft)
+	if m < sp.n {
+		sp.considerMissing(split{axis: axis, value: value}, left, stats(sp.target, sorted[m:]), nLeft+sp.n-m)
+	}
+}
+
+// randomCategorical considers a split of a categorical feature into a random group of the categories of the samples
+// and the rest, sending the samples with NA values to each child in turn
+func (sp *splitter) randomCategorical(axis int, f feature, samples []int) {
+	var present []int
+	seen := make([]bool, len(f.categories))
+	var missingSamples []int
+	for _, i := range samples {
+		if math.IsNaN(f.values[i]) {
+			missingSamples = append(missingSamples, i)
+			continue
+		}
+		if c := int(f.values[i]); !seen[c] {
+			seen[c] = true
+			present = append(present, c)
+		}
+	}
+	if len(present) < 2 {
+		return
+	}
+
+	// Shuffle the categories in ascending order, so the draw depends only on the random source
+	sort.Ints(present)
+	sp.random.Shuffle(len(present), func(i, j int) {
+		present[i], present[j] = present[j], present[i]
+	})
+	categories := make([]bool, len(f.categories))
+	for _, c := range present[:1+sp.random.Intn(len(present)-1)] {
+		categories[c] = true
+	}
+
+	left := make([]float64, len(sp.total))
+	nLeft := 0
+	for _, i := range samples {
+		if !math.IsNaN(f.values[i]) && categories[int(f.values[i])] {
+			sp.target.add(left, i, 1)
+			sp.move(i)
+			nLeft++
+		}
+	}
+
+	sp.consider(split{axis: axis, categories: categories}, left, nLeft)
+	if len(missingSamples) > 0 {
+		sp.considerMissing(split{axis: axis, categories: categories}, left, stats(sp.target, missingSamples),
+			nLeft+len(missingSamples))
+	}
+}
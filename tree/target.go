@@ -0,0 +1,133 @@
+package tree
+
+// target holds the target and weight of each fit sample, and accumulates the weighted statistics of the targets of the
+// samples reaching a node, from which the criterion computes the impurity of the node
+type target interface {
+	// len returns the number of samples
+	len() int
+	// size returns the number of statistics
+	size() int
+	// add adds the weighted target of sample i to the statistics, or removes it if sign is -1
+	add(stats []float64, i int, sign float64)
+	// weight returns the total weight of the samples with the statistics
+	weight(stats []float64) float64
+	// homogeneous returns true if every sample has the same target
+	homogeneous(samples []int) bool
+	// score orders groups of the samples reaching a node, with the statistics total, by their statistics
+	score(stats []float64, total []float64) float64
+	// predict sets the prediction of a node from the statistics of the samples reaching it
+	predict(node *DecisionTree, stats []float64)
+}
+
+// stats returns the statistics of the targets of the samples
+func stats(t target, samples []int) []float64 {
+	stats := make([]float64, t.size())
+	for _, i := range samples {
+		t.add(stats, i, 1)
+	}
+	return stats
+}
+
+// classTarget is the target of a classifier, where y holds the position of the class of each sample in classes. The
+// statistics are the total weight of the samples of each class
+type classTarget struct {
+	y       []int
+	classes []int
+	weights []float64
+}
+
+func (t classTarget) len() int {
+	return len(t.y)
+}
+
+func (t classTarget) size() int {
+	return len(t.classes)
+}
+
+func (t classTarget) add(stats []float64, i int, sign float64) {
+	stats[t.y[i]] += sign * t.weights[i]
+}
+
+func (t classTarget) weight(stats []float64) float64 {
+	weight := 0.0
+	for _, c := range stats {
+		weight += c
+	}
+	return weight
+}
+
+func (t classTarget) homogeneous(samples []int) bool {
+	for _, i := range samples {
+		if t.y[i] != t.y[samples[0]] {
+			return false
+		}
+	}
+	return true
+}
+
+// score returns the weighted fraction of the samples which are of the most common class of the node
+func (t classTarget) score(stats []float64, total []float64) float64 {
+	return stats[majority(total)] / t.weight(stats)
+}
+
+// predict predicts the class with the largest total weight
+func (t classTarget) predict(node *DecisionTree, stats []float64) {
+	node.Label = t.classes[majority(stats)]
+	node.Counts = stats
+}
+
+// majority returns the position of the most common class, preferring the first class on ties
+func majority(counts []float64) int {
+	majority := 0
+	for k, c := range counts {
+		if c > counts[majority] {
+			majority = k
+		}
+	}
+	return majority
+}
+
+// valueTarget is the target of a regressor. The statistics are the total weight of the samples, and the weighted sum
+// and sum of squares of their targets
+type valueTarget struct {
+	y       []float64
+	weights []float64
+}
+
+func (t valueTarget) len() int {
+	return len(t.y)
+}
+
+func (t valueTarget) size() int {
+	return 3
+}
+
+func (t valueTarget) add(stats []float64, i int, sign float64) {
+	w := sign * t.weights[i]
+	stats[0] += w
+	stats[1] += w * t.y[i]
+	stats[2] += w * t.y[i] * t.y[i]
+}
+
+func (t valueTarget) weight(stats []float64) float64 {
+	return stats[0]
+}
+
+func (t valueTarget) homogeneous(samples []int) bool {
+	for _, i := range samples {
+		if t.y[i] != t.y[samples[0]] {
+			return false
+		}
+	}
+	return true
+}
+
+// score returns the weighted mean of the targets
+func (t valueTarget) score(stats []float64, total []float64) float64 {
+	return stats[1] / stats[0]
+}
+
+// predict predicts the weighted mean of the targets
+func (t valueTarget) predict(node *DecisionTree, stats []float64) {
+	node.Output = stats[1] / stats[0]
+}
@@ -2,6 +2,7 @@ package tree
 
 import (
 	"fmt"
+	"github.com/chriso345/golab/dataframe"
 	"math"
 	"strings"
 )
@@ -17,14 +18,187 @@ type DecisionTree struct {
 	// value should always be numeric type, therefore float64 cast should always be safe
 	Value float64
 
+	// Categories holds the categories sent to the left child by a split on a categorical feature, which is nil for a
+	// split on a numeric feature
+	Categories []string
+
+	// MissingLeft sends samples with NA values of the feature to the left child, which is learnt from the fit samples
+	// with NA values, or set if the left child has the larger weight of samples when no fit sample had an NA value.
+	// Otherwise they are sent to the right child
+	MissingLeft bool
+	// MissingSeen is set if fit samples reaching the node had NA values of the feature, so MissingLeft was learnt
+	MissingSeen bool
+
 	// requires integer encoding of labels prior to fitting
 	Label int
+
+	// Counts holds the total weight of the fit samples of each class reaching the node, in ascending order of the
+	// classes, which is their number when the samples are unweighted
+	Counts []float64
+
+	// Output is the weighted mean target of the fit samples reaching the node, predicted by regression trees
+	Output float64
+
+	// Samples is the number of fit samples reaching the node, WeightedSamples is their total weight, and Impurity is
+	// their weighted impurity by the criterion
+	Samples         int
+	WeightedSamples float64
+	Impurity        float64
+
+	// ID is the position of the node in depth first order, with the root at 0
+	ID int
 }
 
 func (dt *DecisionTree) hasChildren() bool {
 	return dt.Left != nil && dt.Right != nil
 }
 
+// Depth returns the number of levels of the tree, where a single leaf has a depth of 1
+func (dt *DecisionTree) Depth() int {
+	if dt == nil {
+		return 0
+	}
+	if dt.Leaf {
+		return 1
+	}
+
+	leftDepth, rightDepth := dt.Left.Depth(), dt.Right.Depth()
+	if leftDepth > rightDepth {
+		return 1 + leftDepth
+	}
+	return 1 + rightDepth
+}
+
+// NLeaves returns the number of leaves of the tree
+func (dt *DecisionTree) NLeaves() int {
+	if dt == nil {
+		return 0
+	}
+	if dt.Leaf {
+		return 1
+	}
+
+	return dt.Left.NLeaves() + dt.Right.NLeaves()
+}
+
+// number sets the ID of each node of the tree in depth first order starting from next, and returns the next ID
+func (dt *DecisionTree) number(next int) int {
+	dt.ID = next
+	next++
+	if !dt.Leaf {
+		next = dt.Left.number(next)
+		next = dt.Right.number(next)
+	}
+	return next
+}
+
+// path returns the nodes of the tree from the root to the leaf reached by the sample at idx of the
+// dataframe.DataFrame
+func (dt *DecisionTree) path(df dataframe.DataFrame, idx int) []*DecisionTree {
+	current := dt
+	path := []*DecisionTree{current}
+	for !current.Leaf {
+		if current.goesLeft(df, idx) {
+			current = current.Left
+		} else {
+			current = current.Right
+		}
+		path = append(path, current)
+	}
+	return path
+}
+
+// importances returns the total decrease in impurity from the splits on each of the features, weighted by the
+// fraction of the weight of the samples reaching each split and normalised to sum to 1
+func (dt *DecisionTree) importances(features int) []float64 {
+	importances := make([]float64, features)
+
+	var visit func(node *DecisionTree)
+	visit = func(node *DecisionTree) {
+		if node.Leaf {
+			return
+		}
+
+		total := dt.WeightedSamples
+		importances[node.Axis] += node.weightedImpurity(total) - node.Left.weightedImpurity(total) -
+			node.Right.weightedImpurity(total)
+		visit(node.Left)
+		visit(node.Right)
+	}
+	visit(dt)
+
+	total := 0.0
+	for _, importance := range importances {
+		total += importance
+	}
+	if total > 0 {
+		for axis := range importances {
+			importances[axis] /= total
+		}
+	}
+	return importances
+}
+
+// goesLeft returns true if the sample at idx of the dataframe.DataFrame is sent to the left child of the split node.
+// Categories which were not seen when fitting are sent right
+func (dt *DecisionTree) goesLeft(df dataframe.DataFrame, idx int) bool {
+	column := df.Columns()[dt.Axis]
+	if column.Elem(idx).IsNA() {
+		return dt.MissingLeft
+	}
+
+	if dt.Categories != nil {
+		v := fmt.Sprintf("%v", column.Val(idx))
+		for _, category := range dt.Categories {
+			if category == v {
+				return true
+			}
+		}
+		return false
+	}
+
+	v := toFloat(column.Val(idx))
+	if math.IsNaN(v) {
+		return dt.MissingLeft
+	}
+	return v <= dt.Value
+}
+
+// toFloat converts the value of a numeric feature to a float
+func toFloat(v any) float64 {
+	switch v_ := v.(type) {
+	case int:
+		return float64(v_)
+	case float64:
+		return v_
+	case bool:
+		if v_ {
+			return 1
+		}
+		return 0
+	default:
+		panic(fmt.Errorf("cannot use value %v of type %T as a numeric feature", v, v))
+	}
+}
+
+// leaf returns the leaf of the tree reached by the sample at idx of the dataframe.DataFrame
+func (dt *DecisionTree) leaf(df dataframe.DataFrame, idx int) *DecisionTree {
+	current := dt
+	for current.hasChildren() {
+		if current.goesLeft(df, idx) {
+			current = current.Left
+		} else {
+			current = current.Right
+		}
+	}
+
+	if current.Leaf {
+		return current
+	}
+
+	panic(fmt.Errorf("current node is not a leaf"))
+}
+
 func (dt *DecisionTree) getStringer(depth int) (string, int, int) {
 	if dt == nil {
 		return "", 0, 0
@@ -38,14 +212,26 @@ func (dt *DecisionTree) getStringer(depth int) (string, int, int) {
 
 	if dt.Leaf {
 		s.WriteString("Leaf: ")
-		s.WriteString(fmt.Sprintf("%v", dt.Label))
+		if dt.Counts == nil {
+			s.WriteString(fmt.Sprintf("%v", dt.Output))
+		} else {
+			s.WriteString(fmt.Sprintf("%v", dt.Label))
+		}
 		s.WriteString("\n")
 		return s.String(), 1, 1
 	}
 	s.WriteString("Axis: ")
 	s.WriteString(fmt.Sprintf("%v", dt.Axis))
-	s.WriteString(", Value: ")
-	s.WriteString(fmt.Sprintf("%v", dt.Value))
+	if dt.Categories != nil {
+		s.WriteString(", Categories: ")
+		s.WriteString(fmt.Sprintf("%v", dt.Categories))
+	} else {
+		s.WriteString(", Value: ")
+		s.WriteString(fmt.Sprintf("%v", dt.Value))
+	}
+	if dt.MissingSeen && dt.MissingLeft {
+		s.WriteString(", Missing: Left")
+	}
 	s.WriteString("\n")
 	leftString, leftLeaf, leftDepth := dt.Left.getStringer(depth + 1)
 	s.WriteString(leftString)